@@ -0,0 +1,280 @@
+package goplex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ValidateToken checks the account's token with plex.tv. A revoked or
+// expired token gives an error matching ErrUnauthorized; any other error
+// means plex.tv couldn't be asked.
+func (user *UserAuthQuery) ValidateToken(ctx context.Context) error {
+	client := clientOrDefault(user.client)
+
+	request, err := client.newPlexRequest(
+		ctx,
+		"GET",
+		"https://plex.tv/api/v2/user",
+		user.AuthToken,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.getResponse(request, http.StatusOK)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	return err
+}
+
+// SignOut revokes the account's token. The UserAuthQuery, and anything
+// created from it, can't be used afterwards.
+func (user *UserAuthQuery) SignOut(ctx context.Context) error {
+	client := clientOrDefault(user.client)
+
+	request, err := client.newPlexRequest(
+		ctx,
+		"DELETE",
+		"https://plex.tv/api/v2/users/signout",
+		user.AuthToken,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.getResponse(request, http.StatusOK, http.StatusNoContent)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	return err
+}
+
+type webhook struct {
+	Url string `json:"url"`
+}
+
+// Webhooks lists the urls plex.tv posts the account's webhook events to.
+// Webhooks need a Plex Pass.
+func (user *UserAuthQuery) Webhooks(ctx context.Context) ([]string, error) {
+	client := clientOrDefault(user.client)
+
+	request, err := client.newPlexRequest(
+		ctx,
+		"GET",
+		"https://plex.tv/api/v2/user/webhooks",
+		user.AuthToken,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", FormatJson.mimeType())
+
+	response, err := client.getResponse(request, http.StatusOK)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var q []webhook
+	err = unmarshalResponse(response, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(q))
+	for _, hook := range q {
+		urls = append(urls, hook.Url)
+	}
+	return urls, nil
+}
+
+// SetWebhooks replaces all of the account's webhooks with urls.
+func (user *UserAuthQuery) SetWebhooks(ctx context.Context, urls []string) error {
+	client := clientOrDefault(user.client)
+
+	form := url.Values{}
+	for _, u := range urls {
+		form.Add("urls[]", u)
+	}
+	if len(urls) == 0 {
+		// plex.tv wants the field present to clear the list
+		form.Set("urls[]", "")
+	}
+
+	request, err := client.newPlexRequest(
+		ctx,
+		"POST",
+		"https://plex.tv/api/v2/user/webhooks",
+		user.AuthToken,
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := client.getResponse(request, http.StatusOK, http.StatusCreated, http.StatusNoContent)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	return err
+}
+
+// AddWebhook registers webhookUrl, unless it's already registered. plex.tv
+// only offers replacing the whole list, so concurrent edits may race.
+func (user *UserAuthQuery) AddWebhook(ctx context.Context, webhookUrl string) error {
+	urls, err := user.Webhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range urls {
+		if u == webhookUrl {
+			return nil
+		}
+	}
+
+	return user.SetWebhooks(ctx, append(urls, webhookUrl))
+}
+
+// DeleteWebhook unregisters webhookUrl. Like AddWebhook it rewrites the
+// whole list.
+func (user *UserAuthQuery) DeleteWebhook(ctx context.Context, webhookUrl string) error {
+	urls, err := user.Webhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	remaining := urls[:0]
+	for _, u := range urls {
+		if u != webhookUrl {
+			remaining = append(remaining, u)
+		}
+	}
+	if len(remaining) == len(urls) {
+		return nil
+	}
+
+	return user.SetWebhooks(ctx, remaining)
+}
+
+// plexTv sends a request to plex.tv as the user and, unless v is nil,
+// decodes the response into it. A url.Values body is sent as a form,
+// anything else as JSON.
+func (user *UserAuthQuery) plexTv(ctx context.Context, method, uri string, body interface{}, v interface{}) error {
+	client := clientOrDefault(user.client)
+
+	var reader io.Reader
+	contentType := ""
+	switch body := body.(type) {
+	case nil:
+	case url.Values:
+		reader = strings.NewReader(body.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	default:
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+		contentType = "application/json"
+	}
+
+	request, err := client.newPlexRequest(ctx, method, uri, user.AuthToken, reader)
+	if err != nil {
+		return err
+	}
+	if len(contentType) > 0 {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := client.getResponse(request, http.StatusOK, http.StatusCreated, http.StatusNoContent)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil || v == nil {
+		return err
+	}
+
+	return unmarshalResponse(response, v)
+}
+
+// Account is the full plex.tv profile of a user.
+type Account struct {
+	Id        int    `xml:"id,attr"`
+	Uuid      string `xml:"uuid,attr"`
+	Username  string `xml:"username,attr"`
+	Title     string `xml:"title,attr"`
+	Email     string `xml:"email,attr"`
+	Thumb     string `xml:"thumb,attr"`
+	Locale    string `xml:"locale,attr"`
+	Country   string `xml:"country,attr"`
+	AuthToken string `xml:"authToken,attr"`
+
+	IsConfirmed  bool `xml:"confirmed,attr"`
+	IsProtected  bool `xml:"protected,attr"`
+	IsRestricted bool `xml:"restricted,attr"`
+	IsHomeAdmin  bool `xml:"homeAdmin,attr"`
+	IsGuest      bool `xml:"guest,attr"`
+	HomeSize     int  `xml:"homeSize,attr"`
+	MaxHomeSize  int  `xml:"maxHomeSize,attr"`
+
+	Subscription *Subscription   `xml:"subscription"`
+	Profile      *AccountProfile `xml:"profile"`
+
+	// Entitlements are the platforms the account may use apps on, e.g.
+	// "ios" or "android", and Roles its roles, e.g. "plexpass".
+	Entitlements []*AccountFeature `xml:"entitlements>entitlement"`
+	Roles        []*AccountFeature `xml:"roles>role"`
+}
+
+// AccountFeature is an entitlement, role or subscription feature.
+type AccountFeature struct {
+	Id string `xml:"id,attr"`
+}
+
+// Subscription is the account's Plex Pass, if it has one.
+type Subscription struct {
+	IsActive       bool   `xml:"active,attr"`
+	Status         string `xml:"status,attr"`
+	Plan           string `xml:"plan,attr"`
+	PaymentService string `xml:"paymentService,attr"`
+	SubscribedAt   string `xml:"subscribedAt,attr"`
+
+	Features []*AccountFeature `xml:"features>feature"`
+}
+
+// AccountProfile holds the account's playback preferences.
+type AccountProfile struct {
+	AutoSelectAudio         bool   `xml:"autoSelectAudio,attr"`
+	DefaultAudioLanguage    string `xml:"defaultAudioLanguage,attr"`
+	DefaultSubtitleLanguage string `xml:"defaultSubtitleLanguage,attr"`
+	AutoSelectSubtitle      int    `xml:"autoSelectSubtitle,attr"`
+}
+
+// HasPlexPass tells whether the account has an active Plex Pass.
+func (account *Account) HasPlexPass() bool {
+	return account.Subscription != nil && account.Subscription.IsActive
+}
+
+// Account fetches the user's profile and subscription from plex.tv.
+func (user *UserAuthQuery) Account(ctx context.Context) (*Account, error) {
+	var account Account
+	err := user.plexTv(ctx, "GET", "https://plex.tv/api/v2/user", nil, &account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
@@ -0,0 +1,30 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+)
+
+type activityContainer struct {
+	Activities []*Activity `xml:"Activity" json:"Activity"`
+}
+
+// Activities lists what the server is busy with: library scans, media
+// analysis, metadata refreshes and so on. Progress is a percentage. For
+// live updates, watch the NotificationActivity events of Notifications
+// instead of polling.
+func (server *PlexServer) Activities(ctx context.Context) ([]*Activity, error) {
+	var container activityContainer
+	err := server.get(ctx, "/activities", nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Activities, nil
+}
+
+// CancelActivity stops the activity with the given uuid. Only activities
+// marked Cancellable can be stopped.
+func (server *PlexServer) CancelActivity(ctx context.Context, uuid string) error {
+	return server.action(ctx, "DELETE", "/activities/"+url.PathEscape(uuid), nil)
+}
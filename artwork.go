@@ -0,0 +1,105 @@
+package goplex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Artwork is a poster or background image available for an item, either
+// from its agent or uploaded.
+type Artwork struct {
+	// Key is what SetPoster and SetArt take.
+	Key       string `xml:"key,attr" json:"key"`
+	RatingKey string `xml:"ratingKey,attr" json:"ratingKey"`
+	Thumb     string `xml:"thumb,attr" json:"thumb"`
+	Provider  string `xml:"provider,attr" json:"provider"`
+	Selected  Bool   `xml:"selected,attr" json:"selected"`
+}
+
+type artworkContainer struct {
+	Artwork []*Artwork `xml:"Photo" json:"Metadata"`
+}
+
+func (item *Item) artwork(ctx context.Context, kind string) ([]*Artwork, error) {
+	var container artworkContainer
+	err := item.server.get(ctx, item.path("/"+kind+"s"), nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Artwork, nil
+}
+
+func (item *Item) selectArtwork(ctx context.Context, kind, key string) error {
+	params := url.Values{}
+	params.Set("url", key)
+
+	return item.server.action(ctx, "PUT", item.path("/"+kind), params)
+}
+
+// upload POSTs a file, e.g. an image or subtitle, to one of the item's
+// endpoints.
+func (item *Item) upload(ctx context.Context, suffix string, params url.Values, file io.Reader) error {
+	request, err := item.server.newRequest(ctx, "POST", item.path(suffix), params, file)
+	if err != nil {
+		return err
+	}
+
+	response, err := clientOrDefault(item.server.client).getResponse(request, http.StatusOK, http.StatusCreated, http.StatusNoContent)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	return err
+}
+
+// Posters lists the posters the item can use.
+func (item *Item) Posters(ctx context.Context) ([]*Artwork, error) {
+	return item.artwork(ctx, "poster")
+}
+
+// SetPoster switches to the poster with the given Artwork Key.
+func (item *Item) SetPoster(ctx context.Context, key string) error {
+	return item.selectArtwork(ctx, "poster", key)
+}
+
+// UploadPoster uploads a JPEG or PNG image and makes it the item's
+// poster.
+func (item *Item) UploadPoster(ctx context.Context, image io.Reader) error {
+	return item.upload(ctx, "/posters", nil, image)
+}
+
+// UploadPosterFromUrl has the server download the image at uri and use
+// it as the item's poster.
+func (item *Item) UploadPosterFromUrl(ctx context.Context, uri string) error {
+	params := url.Values{}
+	params.Set("url", uri)
+
+	return item.upload(ctx, "/posters", params, nil)
+}
+
+// Arts lists the backgrounds the item can use.
+func (item *Item) Arts(ctx context.Context) ([]*Artwork, error) {
+	return item.artwork(ctx, "art")
+}
+
+// SetArt switches to the background with the given Artwork Key.
+func (item *Item) SetArt(ctx context.Context, key string) error {
+	return item.selectArtwork(ctx, "art", key)
+}
+
+// UploadArt uploads a JPEG or PNG image and makes it the item's
+// background.
+func (item *Item) UploadArt(ctx context.Context, image io.Reader) error {
+	return item.upload(ctx, "/arts", nil, image)
+}
+
+// UploadArtFromUrl has the server download the image at uri and use it as
+// the item's background.
+func (item *Item) UploadArtFromUrl(ctx context.Context, uri string) error {
+	params := url.Values{}
+	params.Set("url", uri)
+
+	return item.upload(ctx, "/arts", params, nil)
+}
@@ -0,0 +1,80 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+)
+
+// Some of the butler's task names.
+const (
+	ButlerBackupDatabase          = "BackupDatabase"
+	ButlerCleanOldBundles         = "CleanOldBundles"
+	ButlerCleanOldCacheFiles      = "CleanOldCacheFiles"
+	ButlerDeepMediaAnalysis       = "DeepMediaAnalysis"
+	ButlerGenerateChapterThumbs   = "GenerateChapterThumbs"
+	ButlerOptimizeDatabase        = "OptimizeDatabase"
+	ButlerRefreshLibraries        = "RefreshLibraries"
+	ButlerRefreshLocalMedia       = "RefreshLocalMedia"
+	ButlerRefreshPeriodicMetadata = "RefreshPeriodicMetadata"
+	ButlerUpgradeMediaAnalysis    = "UpgradeMediaAnalysis"
+	ButlerGenerateIntroMarkers    = "GenerateIntroMarkers"
+	ButlerGenerateCreditsMarkers  = "GenerateCreditsMarkers"
+	ButlerGenerateBlurHashes      = "GenerateBlurHashes"
+	ButlerLoudnessAnalysis        = "LoudnessAnalysis"
+	ButlerRefreshEpgGuides        = "RefreshEpgGuides"
+	ButlerGarbageCollectBlobs     = "GarbageCollectBlobs"
+)
+
+// ButlerTask is one of the maintenance tasks the server runs during its
+// scheduled maintenance window.
+type ButlerTask struct {
+	Name               string `xml:"name,attr" json:"name"`
+	Title              string `xml:"title,attr" json:"title"`
+	Description        string `xml:"description,attr" json:"description"`
+	Enabled            Bool   `xml:"enabled,attr" json:"enabled"`
+	Interval           int    `xml:"interval,attr" json:"interval"`
+	ScheduleRandomized Bool   `xml:"scheduleRandomized,attr" json:"scheduleRandomized"`
+}
+
+type butlerContainer struct {
+	Tasks []*ButlerTask `xml:"ButlerTask" json:"ButlerTask"`
+
+	// the JSON response isn't wrapped in a MediaContainer but a
+	// ButlerTasks object
+	ButlerTasks *butlerContainer `xml:"-" json:"ButlerTasks"`
+}
+
+// ButlerTasks lists the butler's tasks. Interval is in days.
+func (server *PlexServer) ButlerTasks(ctx context.Context) ([]*ButlerTask, error) {
+	var container butlerContainer
+	err := server.get(ctx, "/butler", nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	if container.ButlerTasks != nil {
+		return container.ButlerTasks.Tasks, nil
+	}
+	return container.Tasks, nil
+}
+
+// StartButlerTask runs the named task, one of the Butler* constants, now
+// rather than waiting for the maintenance window.
+func (server *PlexServer) StartButlerTask(ctx context.Context, name string) error {
+	return server.action(ctx, "POST", "/butler/"+url.PathEscape(name), nil)
+}
+
+// StopButlerTask stops the named task if it is running.
+func (server *PlexServer) StopButlerTask(ctx context.Context, name string) error {
+	return server.action(ctx, "DELETE", "/butler/"+url.PathEscape(name), nil)
+}
+
+// StartButler runs all of the enabled tasks now.
+func (server *PlexServer) StartButler(ctx context.Context) error {
+	return server.action(ctx, "POST", "/butler", nil)
+}
+
+// StopButler stops every running task.
+func (server *PlexServer) StopButler(ctx context.Context) error {
+	return server.action(ctx, "DELETE", "/butler", nil)
+}
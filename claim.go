@@ -0,0 +1,42 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+)
+
+// ClaimToken fetches a short lived token (valid for a few minutes) that
+// links a server to the account, e.g. for the PLEX_CLAIM variable of the
+// official Docker image.
+func (user *UserAuthQuery) ClaimToken(ctx context.Context) (string, error) {
+	var q struct {
+		Token string `json:"token"`
+	}
+	err := user.plexTv(ctx, "GET", "https://plex.tv/api/claim/token.json", nil, &q)
+	if err != nil {
+		return "", err
+	}
+
+	return q.Token, nil
+}
+
+// Claim links an unclaimed server to the account claimToken belongs to.
+// The server must be reached over its local network, and its Token may be
+// empty.
+func (server *PlexServer) Claim(ctx context.Context, claimToken string) error {
+	params := url.Values{}
+	params.Set("token", claimToken)
+
+	return server.action(ctx, "POST", "/myplex/claim", params)
+}
+
+// ClaimServer links an unclaimed server to the user's account, fetching a
+// claim token for it.
+func (user *UserAuthQuery) ClaimServer(ctx context.Context, server *PlexServer) error {
+	claimToken, err := user.ClaimToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	return server.Claim(ctx, claimToken)
+}
@@ -0,0 +1,173 @@
+package goplex
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client holds the configuration shared by every request: the http client
+// (and so its connection pool and transport) and an optional auth token.
+// A Client is safe for concurrent use once created.
+type Client struct {
+	httpClient *http.Client
+	token      string
+	headers    Headers
+
+	retryPolicy RetryPolicy
+	format      Format
+}
+
+// Headers are the X-Plex-* headers that tell plex.tv and servers which
+// application is calling. plex.tv treats every distinct ClientIdentifier as
+// a separate device, so an application should generate one once and keep
+// reusing it.
+type Headers struct {
+	Product          string
+	Version          string
+	Platform         string
+	PlatformVersion  string
+	Device           string
+	DeviceName       string
+	ClientIdentifier string
+	Provides         string
+}
+
+var DefaultHeaders = Headers{
+	Product:          "go-plex",
+	Version:          "0.0",
+	Platform:         "golang",
+	PlatformVersion:  "0.0",
+	Device:           "platform",
+	ClientIdentifier: "identifier",
+	Provides:         "player,controller",
+}
+
+func (headers *Headers) apply(header http.Header) {
+	set := func(key, value string) {
+		if len(value) > 0 {
+			header.Set(key, value)
+		}
+	}
+
+	set("X-Plex-Product", headers.Product)
+	set("X-Plex-Version", headers.Version)
+	set("X-Plex-Platform", headers.Platform)
+	set("X-Plex-Platform-Version", headers.PlatformVersion)
+	set("X-Plex-Device", headers.Device)
+	set("X-Plex-Device-Name", headers.DeviceName)
+	set("X-Plex-Client-Identifier", headers.ClientIdentifier)
+	set("X-Plex-Provides", headers.Provides)
+}
+
+type Option func(*Client)
+
+// DefaultClient is used by the package level functions such as SignIn.
+var DefaultClient = NewClient()
+
+func NewClient(options ...Option) *Client {
+	client := &Client{
+		httpClient: &http.Client{},
+		headers:    DefaultHeaders,
+	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client
+}
+
+// WithHttpClient makes the Client send everything through httpClient.
+// Options applied after this one (e.g. WithTimeout) modify a copy, never
+// httpClient itself.
+func WithHttpClient(httpClient *http.Client) Option {
+	return func(client *Client) {
+		client.httpClient = httpClient
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(client *Client) {
+		httpClient := *client.httpClient
+		httpClient.Timeout = timeout
+		client.httpClient = &httpClient
+	}
+}
+
+func WithTransport(transport http.RoundTripper) Option {
+	return func(client *Client) {
+		httpClient := *client.httpClient
+		httpClient.Transport = transport
+		client.httpClient = &httpClient
+	}
+}
+
+// WithProxy sends requests through the given proxy. It only has an effect
+// when the transport is an *http.Transport (the default).
+func WithProxy(proxy *url.URL) Option {
+	return func(client *Client) {
+		transport, ok := client.httpClient.Transport.(*http.Transport)
+		if client.httpClient.Transport == nil {
+			transport, ok = http.DefaultTransport.(*http.Transport)
+		}
+		if !ok {
+			return
+		}
+
+		transport = transport.Clone()
+		transport.Proxy = http.ProxyURL(proxy)
+
+		httpClient := *client.httpClient
+		httpClient.Transport = transport
+		client.httpClient = &httpClient
+	}
+}
+
+// WithHeaders replaces the X-Plex-* headers sent with every request. Empty
+// fields are left out.
+func WithHeaders(headers Headers) Option {
+	return func(client *Client) {
+		client.headers = headers
+	}
+}
+
+// WithClientIdentifier keeps the current headers but advertises the given
+// X-Plex-Client-Identifier.
+func WithClientIdentifier(clientIdentifier string) Option {
+	return func(client *Client) {
+		client.headers.ClientIdentifier = clientIdentifier
+	}
+}
+
+// WithRetryPolicy retries requests that failed with a 429, a 5xx or a
+// network error. Retries are off unless this option is given.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(client *Client) {
+		client.retryPolicy = policy
+	}
+}
+
+// WithFormat sets the response format requested from media servers.
+func WithFormat(format Format) Option {
+	return func(client *Client) {
+		client.format = format
+	}
+}
+
+// WithToken sets the auth token used by User, e.g. one saved from an
+// earlier SignIn or PIN link.
+func WithToken(token string) Option {
+	return func(client *Client) {
+		client.token = token
+	}
+}
+
+// User returns the account for the Client's token without contacting
+// plex.tv.
+func (client *Client) User() *UserAuthQuery {
+	return &UserAuthQuery{
+		AuthToken: client.token,
+		client:    client,
+	}
+}
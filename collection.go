@@ -0,0 +1,157 @@
+package goplex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Collection modes, deciding whether a collection is shown in place of
+// its items when browsing the library.
+const (
+	CollectionModeDefault   = -1
+	CollectionModeHide      = 0
+	CollectionModeHideItems = 1
+	CollectionModeShowItems = 2
+)
+
+// Collection sort orders.
+const (
+	CollectionSortRelease = 0
+	CollectionSortTitle   = 1
+	CollectionSortCustom  = 2
+)
+
+type Collection struct {
+	RatingKey      string `xml:"ratingKey,attr" json:"ratingKey"`
+	Key            string `xml:"key,attr" json:"key"`
+	Guid           string `xml:"guid,attr" json:"guid"`
+	Title          string `xml:"title,attr" json:"title"`
+	TitleSort      string `xml:"titleSort,attr" json:"titleSort"`
+	Summary        string `xml:"summary,attr" json:"summary"`
+	Subtype        string `xml:"subtype,attr" json:"subtype"`
+	Smart          Bool   `xml:"smart,attr" json:"smart"`
+	ChildCount     int    `xml:"childCount,attr" json:"childCount"`
+	CollectionMode string `xml:"collectionMode,attr" json:"collectionMode"`
+	CollectionSort string `xml:"collectionSort,attr" json:"collectionSort"`
+	Thumb          string `xml:"thumb,attr" json:"thumb"`
+	AddedAt        int64  `xml:"addedAt,attr" json:"addedAt"`
+	UpdatedAt      int64  `xml:"updatedAt,attr" json:"updatedAt"`
+
+	server *PlexServer
+}
+
+type collectionContainer struct {
+	Collections []*Collection `xml:"Directory" json:"Metadata"`
+}
+
+func (server *PlexServer) collections(ctx context.Context, method, path string, params url.Values) ([]*Collection, error) {
+	var container collectionContainer
+	err := server.fetch(ctx, method, path, params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, collection := range container.Collections {
+		collection.server = server
+	}
+	return container.Collections, nil
+}
+
+// Collections lists the collections in the section.
+func (section *Section) Collections(ctx context.Context) ([]*Collection, error) {
+	return section.server.collections(ctx, "GET", section.path("/collections"), nil)
+}
+
+// CreateCollection creates a collection in the section holding the given
+// items. mediaType is the MediaType of the items, e.g. MediaTypeMovie.
+func (section *Section) CreateCollection(ctx context.Context, title string, mediaType int, ratingKeys ...string) (*Collection, error) {
+	uri, err := section.server.itemsUri(ctx, ratingKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("title", title)
+	params.Set("type", strconv.Itoa(mediaType))
+	params.Set("smart", "0")
+	params.Set("sectionId", section.Key)
+	params.Set("uri", uri)
+
+	collections, err := section.server.collections(ctx, "POST", "/library/collections", params)
+	if err != nil {
+		return nil, err
+	}
+	if len(collections) == 0 {
+		return nil, fmt.Errorf("server didn't return the new collection %q", title)
+	}
+
+	return collections[0], nil
+}
+
+func (collection *Collection) path(suffix string) string {
+	return "/library/collections/" + url.PathEscape(collection.RatingKey) + suffix
+}
+
+// Items lists what is in the collection.
+func (collection *Collection) Items(ctx context.Context) (*MediaContainer, error) {
+	var container MediaContainer
+	err := collection.server.get(ctx, collection.path("/children"), nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
+
+func (collection *Collection) AddItems(ctx context.Context, ratingKeys ...string) error {
+	uri, err := collection.server.itemsUri(ctx, ratingKeys)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("uri", uri)
+
+	return collection.server.action(ctx, "PUT", collection.path("/items"), params)
+}
+
+func (collection *Collection) RemoveItem(ctx context.Context, ratingKey string) error {
+	return collection.server.action(ctx, "DELETE", collection.path("/items/"+url.PathEscape(ratingKey)), nil)
+}
+
+// MoveItem moves an item after another one, or to the front if
+// afterRatingKey is empty. It only has a visible effect with
+// CollectionSortCustom.
+func (collection *Collection) MoveItem(ctx context.Context, ratingKey, afterRatingKey string) error {
+	var params url.Values
+	if len(afterRatingKey) > 0 {
+		params = url.Values{}
+		params.Set("after", afterRatingKey)
+	}
+
+	return collection.server.action(ctx, "PUT", collection.path("/items/"+url.PathEscape(ratingKey)+"/move"), params)
+}
+
+func (collection *Collection) setPref(ctx context.Context, key string, value int) error {
+	params := url.Values{}
+	params.Set(key, strconv.Itoa(value))
+
+	return collection.server.action(ctx, "PUT", "/library/metadata/"+url.PathEscape(collection.RatingKey)+"/prefs", params)
+}
+
+// SetMode takes one of the CollectionMode* constants.
+func (collection *Collection) SetMode(ctx context.Context, mode int) error {
+	return collection.setPref(ctx, "collectionMode", mode)
+}
+
+// SetSort takes one of the CollectionSort* constants.
+func (collection *Collection) SetSort(ctx context.Context, sort int) error {
+	return collection.setPref(ctx, "collectionSort", sort)
+}
+
+// Delete removes the collection. The items in it are left alone.
+func (collection *Collection) Delete(ctx context.Context) error {
+	return collection.server.action(ctx, "DELETE", collection.path(""), nil)
+}
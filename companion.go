@@ -0,0 +1,580 @@
+package goplex
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The companion protocol lets controllers (Plex apps, web, other
+// CompanionPlayers' users) drive a player over plain HTTP:
+//
+//   - the player is found through GDM on the LAN, or through the
+//     connections it published to plex.tv
+//   - controllers send commands to /player/playback/*, /player/navigation/*
+//     and /player/mirror/*
+//   - controllers subscribe to /player/timeline/subscribe and get the
+//     player's state posted back to them, or long-poll
+//     /player/timeline/poll
+
+const (
+	DefaultCompanionPort = 32500
+
+	// GDM group players listen on for searches, and announce themselves to
+	gdmPlayerAnnouncePort = 32413
+
+	// controllers resubscribe every 30 seconds or so
+	companionSubscriberTimeout = 90 * time.Second
+
+	// how long /player/timeline/poll?wait=1 waits for a change
+	companionPollTimeout = 30 * time.Second
+)
+
+type CompanionOptions struct {
+	// Name is what controllers show for the player.
+	Name string
+
+	// MachineIdentifier uniquely identifies the player. It defaults to the
+	// Client's X-Plex-Client-Identifier.
+	MachineIdentifier string
+
+	Product  string
+	Version  string
+	Platform string
+
+	// DeviceClass is "pc", "phone", "tablet" or "stb".
+	DeviceClass string
+
+	// Port is the http port commands are received on, DefaultCompanionPort
+	// if zero.
+	Port int
+}
+
+// PlayerCommand is a command received from a controller.
+type PlayerCommand struct {
+	// Path is what follows /player/, e.g. "playback/playMedia" or
+	// "navigation/moveUp", and Name is its last element.
+	Path   string
+	Name   string
+	Params url.Values
+
+	// Controller is the X-Plex-Client-Identifier of the sender.
+	Controller string
+
+	player *CompanionPlayer
+}
+
+// Offset is the offset parameter of playMedia and seekTo.
+func (command *PlayerCommand) Offset() time.Duration {
+	offset, _ := strconv.ParseInt(command.Params.Get("offset"), 10, 64)
+	return time.Duration(offset) * time.Millisecond
+}
+
+// Server returns the media server a playMedia or mirror command refers to,
+// or nil if the command doesn't name one.
+func (command *PlayerCommand) Server() *PlexServer {
+	address := command.Params.Get("address")
+	if len(address) == 0 {
+		return nil
+	}
+
+	protocol := command.Params.Get("protocol")
+	if len(protocol) == 0 {
+		protocol = "http"
+	}
+
+	uri := protocol + "://" + net.JoinHostPort(address, command.Params.Get("port"))
+	server := clientOrDefault(command.player.client).NewPlexServer(uri, command.Params.Get("token"))
+	server.MachineIdentifier = command.Params.Get("machineIdentifier")
+	return server
+}
+
+// Timeline types and states reported in PlayerTimeline.
+const (
+	TimelineVideo = "video"
+	TimelineMusic = "music"
+	TimelinePhoto = "photo"
+
+	StatePlaying   = "playing"
+	StatePaused    = "paused"
+	StateBuffering = "buffering"
+	StateStopped   = "stopped"
+)
+
+// PlayerTimeline is the state of one kind of playback on the player, as
+// reported to controllers.
+type PlayerTimeline struct {
+	Type  string `xml:"type,attr"`
+	State string `xml:"state,attr"`
+
+	// Time and Duration are in milliseconds.
+	Time     int64 `xml:"time,attr,omitempty"`
+	Duration int64 `xml:"duration,attr,omitempty"`
+
+	RatingKey    string `xml:"ratingKey,attr,omitempty"`
+	Key          string `xml:"key,attr,omitempty"`
+	ContainerKey string `xml:"containerKey,attr,omitempty"`
+
+	PlayQueueId      int `xml:"playQueueID,attr,omitempty"`
+	PlayQueueItemId  int `xml:"playQueueItemID,attr,omitempty"`
+	PlayQueueVersion int `xml:"playQueueVersion,attr,omitempty"`
+
+	// the server the media is played from
+	MachineIdentifier string `xml:"machineIdentifier,attr,omitempty"`
+	Protocol          string `xml:"protocol,attr,omitempty"`
+	Address           string `xml:"address,attr,omitempty"`
+	Port              string `xml:"port,attr,omitempty"`
+
+	Volume  int    `xml:"volume,attr,omitempty"`
+	Shuffle string `xml:"shuffle,attr,omitempty"`
+	Repeat  string `xml:"repeat,attr,omitempty"`
+
+	// Controllable lists the commands the player accepts right now, e.g.
+	// "playPause,stop,seekTo,skipNext,skipPrevious,volume".
+	Controllable string `xml:"controllable,attr,omitempty"`
+}
+
+type companionTimelines struct {
+	XMLName   xml.Name          `xml:"MediaContainer"`
+	CommandId string            `xml:"commandID,attr,omitempty"`
+	Location  string            `xml:"location,attr"`
+	Timelines []*PlayerTimeline `xml:"Timeline"`
+}
+
+type timelineSubscriber struct {
+	controller string
+	uri        string
+	commandId  string
+	lastSeen   time.Time
+}
+
+// CompanionPlayer makes the application a Plex player that controllers can
+// find and send commands to. The application does the actual playing: it
+// gets commands through the handler passed to NewCompanionPlayer and
+// reports what it's doing through UpdateTimeline.
+type CompanionPlayer struct {
+	options CompanionOptions
+	handler func(*PlayerCommand) error
+	client  *Client
+
+	lock        sync.Mutex
+	location    string
+	timelines   map[string]*PlayerTimeline
+	subscribers map[string]*timelineSubscriber
+
+	// closed and replaced whenever the timeline changes, waking pollers
+	changed chan struct{}
+}
+
+// NewCompanionPlayer creates a player whose commands are handed to handler.
+// An error returned by handler is reported back to the controller.
+func (client *Client) NewCompanionPlayer(options CompanionOptions, handler func(*PlayerCommand) error) *CompanionPlayer {
+	if len(options.MachineIdentifier) == 0 {
+		options.MachineIdentifier = client.headers.ClientIdentifier
+	}
+	if len(options.Product) == 0 {
+		options.Product = client.headers.Product
+	}
+	if len(options.Version) == 0 {
+		options.Version = client.headers.Version
+	}
+	if len(options.Platform) == 0 {
+		options.Platform = client.headers.Platform
+	}
+	if len(options.DeviceClass) == 0 {
+		options.DeviceClass = "pc"
+	}
+	if options.Port == 0 {
+		options.Port = DefaultCompanionPort
+	}
+
+	return &CompanionPlayer{
+		options:     options,
+		handler:     handler,
+		client:      client,
+		location:    "navigation",
+		timelines:   make(map[string]*PlayerTimeline),
+		subscribers: make(map[string]*timelineSubscriber),
+		changed:     make(chan struct{}),
+	}
+}
+
+// UpdateTimeline records the player's state for one timeline type and
+// pushes it to subscribed controllers. location is "navigation" while
+// browsing, "fullScreenVideo", "fullScreenMusic" or "fullScreenPhoto" while
+// playing.
+func (player *CompanionPlayer) UpdateTimeline(location string, timeline PlayerTimeline) {
+	player.lock.Lock()
+	player.location = location
+	player.timelines[timeline.Type] = &timeline
+	close(player.changed)
+	player.changed = make(chan struct{})
+	player.lock.Unlock()
+
+	player.pushTimelines()
+}
+
+func (player *CompanionPlayer) currentTimelines(commandId string) *companionTimelines {
+	player.lock.Lock()
+	defer player.lock.Unlock()
+
+	container := &companionTimelines{
+		CommandId: commandId,
+		Location:  player.location,
+	}
+	for _, timelineType := range []string{TimelineMusic, TimelinePhoto, TimelineVideo} {
+		timeline, ok := player.timelines[timelineType]
+		if !ok {
+			timeline = &PlayerTimeline{Type: timelineType, State: StateStopped}
+		}
+		container.Timelines = append(container.Timelines, timeline)
+	}
+
+	return container
+}
+
+// pushTimelines posts the current timelines to every subscriber, dropping
+// the ones that stopped resubscribing or can't be reached.
+func (player *CompanionPlayer) pushTimelines() {
+	// serveCommand updates commandId under the lock, so the pushes get
+	// copies taken here rather than reading it from the goroutines
+	type push struct {
+		subscriber *timelineSubscriber
+		uri        string
+		commandId  string
+	}
+
+	player.lock.Lock()
+	pushes := make([]push, 0, len(player.subscribers))
+	for controller, subscriber := range player.subscribers {
+		if time.Since(subscriber.lastSeen) > companionSubscriberTimeout {
+			delete(player.subscribers, controller)
+			continue
+		}
+		pushes = append(pushes, push{subscriber, subscriber.uri, subscriber.commandId})
+	}
+	player.lock.Unlock()
+
+	for _, p := range pushes {
+		go func(p push) {
+			err := player.pushTimeline(p.uri, p.commandId)
+			if err != nil {
+				player.lock.Lock()
+				if player.subscribers[p.subscriber.controller] == p.subscriber {
+					delete(player.subscribers, p.subscriber.controller)
+				}
+				player.lock.Unlock()
+			}
+		}(p)
+	}
+}
+
+// pushTimeline posts the current timelines to the controller listening at
+// uri.
+func (player *CompanionPlayer) pushTimeline(uri, commandId string) error {
+	body, err := xml.Marshal(player.currentTimelines(commandId))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request, err := player.client.newPlexRequest(ctx, "POST", uri+"/:/timeline", "", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/xml")
+	request.Header.Set("X-Plex-Client-Identifier", player.options.MachineIdentifier)
+
+	response, err := player.client.getResponse(request, http.StatusOK)
+	if response != nil {
+		response.Body.Close()
+	}
+	return err
+}
+
+func (player *CompanionPlayer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+	header.Set("Access-Control-Allow-Origin", "*")
+	header.Set("Access-Control-Allow-Headers", "*")
+	header.Set("Access-Control-Expose-Headers", "X-Plex-Client-Identifier")
+	header.Set("X-Plex-Client-Identifier", player.options.MachineIdentifier)
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/resources":
+		player.serveResources(w)
+	case r.URL.Path == "/player/timeline/subscribe":
+		player.subscribe(w, r)
+	case r.URL.Path == "/player/timeline/unsubscribe":
+		player.lock.Lock()
+		delete(player.subscribers, r.Header.Get("X-Plex-Client-Identifier"))
+		player.lock.Unlock()
+	case r.URL.Path == "/player/timeline/poll":
+		player.poll(w, r)
+	case strings.HasPrefix(r.URL.Path, "/player/"):
+		player.serveCommand(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (player *CompanionPlayer) serveResources(w http.ResponseWriter) {
+	resources := struct {
+		XMLName xml.Name `xml:"MediaContainer"`
+		Player  struct {
+			Title                string `xml:"title,attr"`
+			MachineIdentifier    string `xml:"machineIdentifier,attr"`
+			Product              string `xml:"product,attr"`
+			Version              string `xml:"version,attr"`
+			Platform             string `xml:"platform,attr"`
+			DeviceClass          string `xml:"deviceClass,attr"`
+			ProtocolVersion      string `xml:"protocolVersion,attr"`
+			ProtocolCapabilities string `xml:"protocolCapabilities,attr"`
+		}
+	}{}
+	resources.Player.Title = player.options.Name
+	resources.Player.MachineIdentifier = player.options.MachineIdentifier
+	resources.Player.Product = player.options.Product
+	resources.Player.Version = player.options.Version
+	resources.Player.Platform = player.options.Platform
+	resources.Player.DeviceClass = player.options.DeviceClass
+	resources.Player.ProtocolVersion = "1"
+	resources.Player.ProtocolCapabilities = "timeline,playback,navigation,mirror,playqueues"
+
+	writeXml(w, resources)
+}
+
+func (player *CompanionPlayer) subscribe(w http.ResponseWriter, r *http.Request) {
+	controller := r.Header.Get("X-Plex-Client-Identifier")
+	if len(controller) == 0 {
+		http.Error(w, "missing X-Plex-Client-Identifier", http.StatusBadRequest)
+		return
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	protocol := r.URL.Query().Get("protocol")
+	if len(protocol) == 0 {
+		protocol = "http"
+	}
+
+	uri := protocol + "://" + net.JoinHostPort(host, r.URL.Query().Get("port"))
+	commandId := r.URL.Query().Get("commandID")
+
+	player.lock.Lock()
+	player.subscribers[controller] = &timelineSubscriber{
+		controller: controller,
+		uri:        uri,
+		commandId:  commandId,
+		lastSeen:   time.Now(),
+	}
+	player.lock.Unlock()
+
+	// controllers expect the current state straight away
+	go player.pushTimeline(uri, commandId)
+}
+
+func (player *CompanionPlayer) poll(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("wait") == "1" {
+		player.lock.Lock()
+		changed := player.changed
+		player.lock.Unlock()
+
+		timer := time.NewTimer(companionPollTimeout)
+		defer timer.Stop()
+		select {
+		case <-changed:
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	writeXml(w, player.currentTimelines(r.URL.Query().Get("commandID")))
+}
+
+func (player *CompanionPlayer) serveCommand(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/player/")
+	command := &PlayerCommand{
+		Path:       path,
+		Name:       path[strings.LastIndex(path, "/")+1:],
+		Params:     r.URL.Query(),
+		Controller: r.Header.Get("X-Plex-Client-Identifier"),
+		player:     player,
+	}
+
+	player.lock.Lock()
+	if subscriber, ok := player.subscribers[command.Controller]; ok {
+		subscriber.commandId = command.Params.Get("commandID")
+		subscriber.lastSeen = time.Now()
+	}
+	player.lock.Unlock()
+
+	err := player.handler(command)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeXml(w, struct {
+		XMLName xml.Name `xml:"Response"`
+		Code    int      `xml:"code,attr"`
+		Status  string   `xml:"status,attr"`
+	}{Code: 200, Status: "OK"})
+}
+
+func writeXml(w http.ResponseWriter, v interface{}) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	w.Write(body)
+}
+
+// gdmHeaders is how the player describes itself in GDM responses and
+// announcements.
+func (player *CompanionPlayer) gdmHeaders() string {
+	return fmt.Sprintf(
+		"Content-Type: plex/media-player\r\n"+
+			"Resource-Identifier: %s\r\n"+
+			"Name: %s\r\n"+
+			"Port: %d\r\n"+
+			"Product: %s\r\n"+
+			"Version: %s\r\n"+
+			"Protocol: plex\r\n"+
+			"Protocol-Version: 1\r\n"+
+			"Protocol-Capabilities: timeline,playback,navigation,mirror,playqueues\r\n"+
+			"Device-Class: %s\r\n",
+		player.options.MachineIdentifier,
+		player.options.Name,
+		player.options.Port,
+		player.options.Product,
+		player.options.Version,
+		player.options.DeviceClass,
+	)
+}
+
+// ListenAndServe accepts commands on the configured port and answers GDM
+// searches until ctx is done.
+func (player *CompanionPlayer) ListenAndServe(ctx context.Context) error {
+	httpServer := &http.Server{
+		Addr:    ":" + strconv.Itoa(player.options.Port),
+		Handler: player,
+	}
+
+	gdm, err := net.ListenMulticastUDP("udp4", nil, &net.UDPAddr{
+		IP:   net.ParseIP(gdmMulticastAddress),
+		Port: gdmPlayerPort,
+	})
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		player.serveGdm(gdm)
+	}()
+
+	player.announce("HELLO")
+
+	stop := context.AfterFunc(ctx, func() {
+		player.announce("BYE")
+		gdm.Close()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	})
+	defer stop()
+
+	err = httpServer.ListenAndServe()
+	if !errors.Is(err, http.ErrServerClosed) {
+		// make sure the GDM responder stops as well
+		gdm.Close()
+		wg.Wait()
+		return err
+	}
+
+	wg.Wait()
+	return nil
+}
+
+func (player *CompanionPlayer) serveGdm(conn *net.UDPConn) {
+	buffer := make([]byte, 1024)
+	for {
+		n, addr, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+
+		if !bytes.HasPrefix(buffer[:n], []byte("M-SEARCH")) {
+			continue
+		}
+
+		response := "HTTP/1.0 200 OK\r\n" + player.gdmHeaders() + "\r\n"
+		conn.WriteToUDP([]byte(response), addr)
+	}
+}
+
+// announce tells the LAN the player came (HELLO) or went (BYE), so
+// controllers don't have to wait for their next search.
+func (player *CompanionPlayer) announce(verb string) {
+	conn, err := net.Dial("udp4", net.JoinHostPort(gdmMulticastAddress, strconv.Itoa(gdmPlayerAnnouncePort)))
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	conn.Write([]byte(verb + " * HTTP/1.0\r\n" + player.gdmHeaders() + "\r\n"))
+}
+
+// Publish registers the uris the player can be reached on with plex.tv, so
+// controllers signed in to the same account can find it outside the LAN
+// (or where GDM doesn't work).
+func (player *CompanionPlayer) Publish(ctx context.Context, user *UserAuthQuery, uris ...string) error {
+	params := url.Values{}
+	for _, uri := range uris {
+		params.Add("Connection[][uri]", uri)
+	}
+
+	request, err := player.client.newPlexRequest(
+		ctx,
+		"PUT",
+		"https://plex.tv/devices/"+url.PathEscape(player.options.MachineIdentifier)+"?"+params.Encode(),
+		user.AuthToken,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("X-Plex-Client-Identifier", player.options.MachineIdentifier)
+
+	response, err := player.client.getResponse(request, http.StatusOK, http.StatusCreated)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package goplex
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// timelineController records the timelines a CompanionPlayer pushes to it.
+type timelineController struct {
+	server *httptest.Server
+	pushes chan struct{}
+}
+
+func newTimelineController(t *testing.T) *timelineController {
+	controller := &timelineController{pushes: make(chan struct{}, 100)}
+	controller.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/:/timeline" {
+			controller.pushes <- struct{}{}
+		}
+	}))
+	t.Cleanup(controller.server.Close)
+	return controller
+}
+
+func (controller *timelineController) port(t *testing.T) string {
+	uri, err := url.Parse(controller.server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, port, err := net.SplitHostPort(uri.Host)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return port
+}
+
+func (controller *timelineController) waitForPush(t *testing.T) {
+	select {
+	case <-controller.pushes:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no timeline pushed")
+	}
+}
+
+func serveCompanion(player *CompanionPlayer, controllerId, uri string) {
+	request := httptest.NewRequest("GET", uri, nil)
+	request.RemoteAddr = "127.0.0.1:50000"
+	request.Header.Set("X-Plex-Client-Identifier", controllerId)
+	player.ServeHTTP(httptest.NewRecorder(), request)
+}
+
+func TestCompanionPlayerConcurrentSubscribers(t *testing.T) {
+	player := NewClient().NewCompanionPlayer(CompanionOptions{Name: "test"}, func(*PlayerCommand) error {
+		return nil
+	})
+
+	controllers := []*timelineController{newTimelineController(t), newTimelineController(t)}
+	for i, controller := range controllers {
+		serveCompanion(player, fmt.Sprintf("controller-%d", i), "/player/timeline/subscribe?commandID=0&port="+controller.port(t))
+		controller.waitForPush(t)
+	}
+
+	// commands update each subscriber's commandID while timelines are
+	// being pushed to it
+	var wg sync.WaitGroup
+	for i := range controllers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for commandId := 1; commandId <= 20; commandId++ {
+				serveCompanion(player, fmt.Sprintf("controller-%d", i), fmt.Sprintf("/player/playback/play?commandID=%d", commandId))
+			}
+		}(i)
+	}
+	for i := 0; i < 20; i++ {
+		player.UpdateTimeline("fullScreenVideo", PlayerTimeline{Type: TimelineVideo, State: StatePlaying, Time: int64(i)})
+	}
+	wg.Wait()
+
+	for _, controller := range controllers {
+		controller.waitForPush(t)
+	}
+}
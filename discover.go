@@ -0,0 +1,68 @@
+package goplex
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DiscoverResult is a movie or show found by DiscoverSearch. Metadata.Guid
+// is the Plex guid the Watchlist functions take.
+type DiscoverResult struct {
+	Score    float64 `json:"score"`
+	Metadata *Video  `json:"Metadata"`
+}
+
+// DiscoverSearch looks for movies and shows in Plex's own catalogue rather
+// than in a server's libraries, best matches first. A limit of 0 lets
+// plex.tv decide how many to return.
+func (user *UserAuthQuery) DiscoverSearch(ctx context.Context, query string, limit int) ([]*DiscoverResult, error) {
+	client := clientOrDefault(user.client)
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("searchTypes", "movies,tv")
+	params.Set("searchProviders", "discover")
+	params.Set("includeMetadata", "1")
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	request, err := client.newPlexRequest(
+		ctx,
+		"GET",
+		"https://discover.provider.plex.tv/library/search?"+params.Encode(),
+		user.AuthToken,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// search results only come as JSON
+	request.Header.Set("Accept", FormatJson.mimeType())
+
+	response, err := client.getResponse(request, http.StatusOK)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var q struct {
+		SearchResults []struct {
+			SearchResult []*DiscoverResult `json:"SearchResult"`
+		} `json:"SearchResults"`
+	}
+	err = unmarshalResponse(response, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*DiscoverResult
+	for _, group := range q.SearchResults {
+		results = append(results, group.SearchResult...)
+	}
+	return results, nil
+}
@@ -0,0 +1,98 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tag types that can be edited with AddTags and RemoveTags.
+const (
+	TagGenre      = "genre"
+	TagLabel      = "label"
+	TagCollection = "collection"
+	TagDirector   = "director"
+	TagWriter     = "writer"
+	TagCountry    = "country"
+	TagMood       = "mood"
+	TagStyle      = "style"
+)
+
+// Edit collects changes to an item's metadata for Item.Edit:
+//
+//	edit := NewEdit().Title("Alien").Summary("In space...").
+//		AddTags(TagGenre, "Horror").RemoveTags(TagLabel, "Watch later")
+//
+// Changed fields are locked so the agent doesn't overwrite them on the
+// next refresh; Unlock hands them back.
+type Edit struct {
+	params url.Values
+	added  map[string]int
+}
+
+func NewEdit() *Edit {
+	return &Edit{params: url.Values{}, added: map[string]int{}}
+}
+
+// Set changes any field by name, e.g. "studio" or "tagline", and locks it.
+func (edit *Edit) Set(field, value string) *Edit {
+	edit.params.Set(field+".value", value)
+	return edit.Lock(field)
+}
+
+func (edit *Edit) Title(title string) *Edit {
+	return edit.Set("title", title)
+}
+
+func (edit *Edit) TitleSort(titleSort string) *Edit {
+	return edit.Set("titleSort", titleSort)
+}
+
+func (edit *Edit) Summary(summary string) *Edit {
+	return edit.Set("summary", summary)
+}
+
+func (edit *Edit) ContentRating(contentRating string) *Edit {
+	return edit.Set("contentRating", contentRating)
+}
+
+func (edit *Edit) OriginallyAvailableAt(date time.Time) *Edit {
+	return edit.Set("originallyAvailableAt", date.Format("2006-01-02"))
+}
+
+// AddTags adds tags of the given type (one of the Tag* constants), e.g.
+// genres, keeping the ones already there.
+func (edit *Edit) AddTags(tagType string, tags ...string) *Edit {
+	for _, tag := range tags {
+		index := edit.added[tagType]
+		edit.added[tagType] = index + 1
+		edit.params.Set(tagType+"["+strconv.Itoa(index)+"].tag.tag", tag)
+	}
+	return edit.Lock(tagType)
+}
+
+// RemoveTags removes tags of the given type from the item.
+func (edit *Edit) RemoveTags(tagType string, tags ...string) *Edit {
+	edit.params.Set(tagType+"[].tag.tag-", strings.Join(tags, ","))
+	return edit.Lock(tagType)
+}
+
+// Lock stops the agent from changing field on refresh without changing its
+// value.
+func (edit *Edit) Lock(field string) *Edit {
+	edit.params.Set(field+".locked", "1")
+	return edit
+}
+
+// Unlock lets the agent update field again on the next refresh.
+func (edit *Edit) Unlock(field string) *Edit {
+	edit.params.Set(field+".locked", "0")
+	return edit
+}
+
+// Edit applies the changes to the item's metadata.
+func (item *Item) Edit(ctx context.Context, edit *Edit) error {
+	return item.server.action(ctx, "PUT", item.path(""), edit.params)
+}
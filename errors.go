@@ -0,0 +1,119 @@
+package goplex
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Sentinel errors matched by HttpStatusError through errors.Is, e.g.
+// errors.Is(err, ErrUnauthorized) for a bad or revoked token.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServerError  = errors.New("server error")
+)
+
+// how much of an error response body is kept on HttpStatusError
+const bodyExcerptSize = 512
+
+// HttpStatusError is returned when plex.tv or a server answers with a
+// status code the call didn't expect.
+type HttpStatusError struct {
+	StatusCode int
+	Method     string
+
+	// Url is the request's url with tokens and pins hidden.
+	Url string
+
+	// Body holds the start of the response body, which usually explains
+	// what went wrong.
+	Body string
+
+	// RetryAfter is how long the server asked us to wait before trying
+	// again, if it said so.
+	RetryAfter time.Duration
+}
+
+func newHttpStatusError(response *http.Response) *HttpStatusError {
+	e := &HttpStatusError{
+		StatusCode: response.StatusCode,
+		RetryAfter: parseRetryAfter(response.Header.Get("Retry-After")),
+	}
+
+	if response.Request != nil {
+		e.Method = response.Request.Method
+		e.Url = redactUrl(response.Request.URL)
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, bodyExcerptSize))
+	e.Body = string(body)
+
+	return e
+}
+
+// credentials that calls pass in the query string
+var secretParams = []string{"X-Plex-Token", "token", "pin"}
+
+// redactUrl hides the password and any credentials in the query of uri,
+// like url.URL.Redacted, so errors can be logged safely.
+func redactUrl(uri *url.URL) string {
+	redacted := *uri
+
+	query := redacted.Query()
+	changed := false
+	for _, param := range secretParams {
+		if _, ok := query[param]; ok {
+			query.Set(param, "xxxxx")
+			changed = true
+		}
+	}
+	if changed {
+		redacted.RawQuery = query.Encode()
+	}
+
+	return redacted.Redacted()
+}
+
+func (e *HttpStatusError) Error() string {
+	return fmt.Sprintf("%s %s: HTTP=%d %s", e.Method, e.Url, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *HttpStatusError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// parseRetryAfter understands both forms of the Retry-After header, a
+// number of seconds or an http date.
+func parseRetryAfter(value string) time.Duration {
+	if len(value) == 0 {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
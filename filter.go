@@ -0,0 +1,152 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Filter operators. Text fields match on Is (contains) and IsNot, numbers
+// and dates also on GreaterThan and LessThan.
+const (
+	OpIs          = "="
+	OpIsNot       = "!="
+	OpIsExactly   = "=="
+	OpGreaterThan = ">>="
+	OpLessThan    = "<<="
+)
+
+// Filter builds the query for Section.All. Conditions are and-ed together
+// unless grouped with Push, Or and Pop:
+//
+//	filter := NewFilter().Type(MediaTypeMovie).Unwatched().
+//		Push().Genre("Comedy").Or().Genre("Animation").Pop().
+//		Sort("year", true)
+//
+// The order of the calls matters, so a Filter can't be built from
+// url.Values.
+type Filter struct {
+	terms []string
+	sort  []string
+}
+
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// Where adds the condition field op value, e.g. Where("year", OpLessThan,
+// "1980").
+func (filter *Filter) Where(field, op, value string) *Filter {
+	key := field + strings.TrimSuffix(op, "=")
+	filter.terms = append(filter.terms, url.QueryEscape(key)+"="+url.QueryEscape(value))
+	return filter
+}
+
+// Type limits the results to one of the MediaType constants, e.g. the
+// episodes rather than the shows of a TV section.
+func (filter *Filter) Type(mediaType int) *Filter {
+	return filter.Where("type", OpIs, strconv.Itoa(mediaType))
+}
+
+// Genre takes the genre's name or tag id.
+func (filter *Filter) Genre(genre string) *Filter {
+	return filter.Where("genre", OpIs, genre)
+}
+
+// Label takes the label's name or tag id.
+func (filter *Filter) Label(label string) *Filter {
+	return filter.Where("label", OpIs, label)
+}
+
+// Actor takes the actor's name or tag id.
+func (filter *Filter) Actor(actor string) *Filter {
+	return filter.Where("actor", OpIs, actor)
+}
+
+// Resolution takes a video resolution as found in
+// Media.VideoResolution, e.g. "4k", "1080" or "sd".
+func (filter *Filter) Resolution(resolution string) *Filter {
+	return filter.Where("resolution", OpIs, resolution)
+}
+
+// Years keeps items released from one year up to and including another.
+// Either bound may be 0 to leave it open.
+func (filter *Filter) Years(from, to int) *Filter {
+	if from > 0 {
+		filter.Where("year", OpGreaterThan, strconv.Itoa(from-1))
+	}
+	if to > 0 {
+		filter.Where("year", OpLessThan, strconv.Itoa(to+1))
+	}
+	return filter
+}
+
+// AddedWithin keeps items added to the library in the past number of days.
+func (filter *Filter) AddedWithin(days int) *Filter {
+	return filter.Where("addedAt", OpGreaterThan, "-"+strconv.Itoa(days)+"d")
+}
+
+func (filter *Filter) Unwatched() *Filter {
+	return filter.Where("unwatched", OpIs, "1")
+}
+
+// Push opens a group of conditions, closed again by Pop.
+func (filter *Filter) Push() *Filter {
+	filter.terms = append(filter.terms, "push=1")
+	return filter
+}
+
+// Or makes the conditions on either side of it alternatives rather than
+// requirements. It is only allowed inside a group.
+func (filter *Filter) Or() *Filter {
+	filter.terms = append(filter.terms, "or=1")
+	return filter
+}
+
+// Pop closes the group opened by the last Push.
+func (filter *Filter) Pop() *Filter {
+	filter.terms = append(filter.terms, "pop=1")
+	return filter
+}
+
+// Sort orders the results by field, e.g. "titleSort", "addedAt" or
+// "rating". Later calls break ties left by earlier ones.
+func (filter *Filter) Sort(field string, descending bool) *Filter {
+	if descending {
+		field += ":desc"
+	}
+	filter.sort = append(filter.sort, field)
+	return filter
+}
+
+func (filter *Filter) encode() string {
+	terms := filter.terms
+	if len(filter.sort) > 0 {
+		terms = append(terms[:len(terms):len(terms)], "sort="+url.QueryEscape(strings.Join(filter.sort, ",")))
+	}
+	return strings.Join(terms, "&")
+}
+
+// All lists the section's items matching filter, starting at start. A nil
+// filter matches everything and a size of 0 lets the server decide how
+// many to return.
+func (section *Section) All(ctx context.Context, filter *Filter, start, size int) (*MediaContainer, error) {
+	path := section.path("/all")
+	if filter != nil {
+		if query := filter.encode(); len(query) > 0 {
+			path += "?" + query
+		}
+	}
+
+	params := url.Values{}
+	setPaging(params, start, size)
+
+	var container MediaContainer
+	err := section.server.get(ctx, path, params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
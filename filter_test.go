@@ -0,0 +1,48 @@
+package goplex
+
+import (
+	"testing"
+)
+
+func TestFilterEncode(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *Filter
+		query  string
+	}{
+		{"empty", NewFilter(), ""},
+		{"type", NewFilter().Type(MediaTypeMovie), "type=1"},
+		{"is not", NewFilter().Where("studio", OpIsNot, "Pixar"), "studio%21=Pixar"},
+		{"is exactly", NewFilter().Where("title", OpIsExactly, "Up"), "title%3D=Up"},
+		{"escaped value", NewFilter().Genre("Sci-Fi & Fantasy"), "genre=Sci-Fi+%26+Fantasy"},
+		{"years", NewFilter().Years(1990, 1999), "year%3E%3E=1989&year%3C%3C=2000"},
+		{"open ended years", NewFilter().Years(0, 1979), "year%3C%3C=1980"},
+		{"added within", NewFilter().AddedWithin(30), "addedAt%3E%3E=-30d"},
+		{"sort", NewFilter().Sort("rating", true).Sort("titleSort", false), "sort=rating%3Adesc%2CtitleSort"},
+		{"groups", NewFilter().Type(MediaTypeMovie).Unwatched().
+			Push().Genre("Comedy").Or().Genre("Animation").Pop().
+			Years(1990, 1999).Sort("year", true).Sort("titleSort", false),
+			"type=1&unwatched=1&push=1&genre=Comedy&or=1&genre=Animation&pop=1&year%3E%3E=1989&year%3C%3C=2000&sort=year%3Adesc%2CtitleSort"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if query := test.filter.encode(); query != test.query {
+				t.Errorf("encode() = %q, want %q", query, test.query)
+			}
+		})
+	}
+}
+
+func TestFilterEncodeRepeatable(t *testing.T) {
+	filter := NewFilter().Type(MediaTypeMovie).Sort("year", false)
+	if query := filter.encode(); query != "type=1&sort=year" {
+		t.Errorf("encode() = %q", query)
+	}
+
+	// encoding must not add the sort to the filter's own terms
+	filter.Unwatched()
+	if query := filter.encode(); query != "type=1&unwatched=1&sort=year" {
+		t.Errorf("encode() = %q after adding a term", query)
+	}
+}
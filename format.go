@@ -0,0 +1,126 @@
+package goplex
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// Format is the response encoding asked for with the Accept header. Plex
+// Media Server speaks both; its JSON responses tend to carry more fields.
+// plex.tv calls always use XML, whatever the Client prefers.
+type Format int
+
+const (
+	FormatXml Format = iota
+	FormatJson
+)
+
+func (format Format) mimeType() string {
+	if format == FormatJson {
+		return "application/json"
+	}
+	return "application/xml"
+}
+
+func isJsonResponse(response *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// unmarshalJson decodes a JSON body into v. Media servers wrap every
+// response in a MediaContainer object, the JSON equivalent of the XML root
+// element, which is unwrapped so the same struct can decode either format.
+func unmarshalJson(body []byte, v interface{}) error {
+	var envelope struct {
+		MediaContainer json.RawMessage
+	}
+	err := json.Unmarshal(body, &envelope)
+	if err == nil && len(envelope.MediaContainer) > 0 {
+		body = envelope.MediaContainer
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// directoryTypes are the types that come back as Directory elements in XML
+// but as Metadata in JSON.
+var directoryTypes = map[string]bool{
+	"show":   true,
+	"season": true,
+	"artist": true,
+	"album":  true,
+}
+
+// unmarshalJsonMetadata decodes one JSON Metadata item into a Directory or
+// a Video, whichever the XML response would have used for it.
+func unmarshalJsonMetadata(raw json.RawMessage) (*Directory, *Video, error) {
+	var item struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(raw, &item)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if directoryTypes[item.Type] {
+		var directory Directory
+		err = json.Unmarshal(raw, &directory)
+		return &directory, nil, err
+	}
+
+	var video Video
+	err = json.Unmarshal(raw, &video)
+	return nil, &video, err
+}
+
+// UnmarshalJSON sorts the Metadata items into Directories and Videos the
+// way XML responses do, so a listing comes back the same in either format.
+func (container *MediaContainer) UnmarshalJSON(data []byte) error {
+	type mediaContainer MediaContainer
+	var q struct {
+		mediaContainer
+		Metadata []json.RawMessage `json:"Metadata"`
+	}
+	err := json.Unmarshal(data, &q)
+	if err != nil {
+		return err
+	}
+
+	*container = MediaContainer(q.mediaContainer)
+	for _, raw := range q.Metadata {
+		directory, video, err := unmarshalJsonMetadata(raw)
+		if err != nil {
+			return err
+		}
+		if directory != nil {
+			container.Directories = append(container.Directories, directory)
+		} else {
+			container.Videos = append(container.Videos, video)
+		}
+	}
+	return nil
+}
+
+// Bool decodes flags that media servers send as either true/false or 1/0
+// depending on the field, format and server version.
+type Bool bool
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Unquote(string(data))
+	if err != nil {
+		value = string(data)
+	}
+	if value == "null" || len(value) == 0 {
+		*b = false
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*b = Bool(parsed)
+	return nil
+}
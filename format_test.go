@@ -0,0 +1,120 @@
+package goplex
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalJsonMediaContainer(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"wrapped", `{"MediaContainer":{"size":2,"totalSize":10,"title1":"Movies","Metadata":[{"ratingKey":"1"},{"ratingKey":"2"}]}}`},
+		{"unwrapped", `{"size":2,"totalSize":10,"title1":"Movies","Metadata":[{"ratingKey":"1"},{"ratingKey":"2"}]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var container MediaContainer
+			err := unmarshalJson([]byte(test.body), &container)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if container.Size != 2 || container.TotalSize != 10 || container.Title1 != "Movies" {
+				t.Errorf("got size=%d totalSize=%d title1=%q", container.Size, container.TotalSize, container.Title1)
+			}
+			if len(container.Videos) != 2 || container.Videos[1].RatingKey != "2" {
+				t.Errorf("got %d videos", len(container.Videos))
+			}
+		})
+	}
+}
+
+func TestUnmarshalJsonInvalid(t *testing.T) {
+	var container MediaContainer
+	if err := unmarshalJson([]byte(`{"MediaContainer":`), &container); err == nil {
+		t.Error("expected an error for truncated JSON")
+	}
+}
+
+func TestUnmarshalResponseFormat(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+	}{
+		{"application/xml", `<MediaContainer size="3" title1="Shows"></MediaContainer>`},
+		{"text/xml;charset=utf-8", `<MediaContainer size="3" title1="Shows"></MediaContainer>`},
+		{"application/json", `{"MediaContainer":{"size":3,"title1":"Shows"}}`},
+		{"application/json; charset=utf-8", `{"MediaContainer":{"size":3,"title1":"Shows"}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.contentType, func(t *testing.T) {
+			response := &http.Response{
+				Header: http.Header{"Content-Type": {test.contentType}},
+				Body:   io.NopCloser(strings.NewReader(test.body)),
+			}
+
+			var container MediaContainer
+			err := unmarshalResponse(response, &container)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if container.Size != 3 || container.Title1 != "Shows" {
+				t.Errorf("got size=%d title1=%q", container.Size, container.Title1)
+			}
+		})
+	}
+}
+
+func TestBoolUnmarshalJson(t *testing.T) {
+	tests := []struct {
+		data  string
+		value Bool
+	}{
+		{`true`, true},
+		{`false`, false},
+		{`1`, true},
+		{`0`, false},
+		{`"1"`, true},
+		{`"0"`, false},
+		{`"true"`, true},
+		{`""`, false},
+		{`null`, false},
+	}
+
+	for _, test := range tests {
+		value := !test.value
+		err := value.UnmarshalJSON([]byte(test.data))
+		if err != nil {
+			t.Errorf("%s: %v", test.data, err)
+			continue
+		}
+		if value != test.value {
+			t.Errorf("%s: got %v, want %v", test.data, value, test.value)
+		}
+	}
+
+	var value Bool
+	if err := value.UnmarshalJSON([]byte(`"yes"`)); err == nil {
+		t.Error(`expected an error for "yes"`)
+	}
+}
+
+func TestBoolInStruct(t *testing.T) {
+	var q struct {
+		Smart Bool `json:"smart"`
+		More  Bool `json:"more"`
+	}
+	err := unmarshalJson([]byte(`{"MediaContainer":{"smart":1,"more":false}}`), &q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Smart || q.More {
+		t.Errorf("got smart=%v more=%v", q.Smart, q.More)
+	}
+}
@@ -0,0 +1,155 @@
+package goplex
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// GDM ("G'Day Mate") is Plex's LAN discovery protocol: an M-SEARCH sent to a
+// multicast group is answered by every server (port 32414) or player (port
+// 32412) on the network with a small HTTP style response.
+const (
+	gdmMulticastAddress = "239.0.0.250"
+	gdmServerPort       = 32414
+	gdmPlayerPort       = 32412
+)
+
+var gdmSearch = []byte("M-SEARCH * HTTP/1.0\r\n\r\n")
+
+// GdmDevice is a server or player that answered a GDM search.
+type GdmDevice struct {
+	ResourceIdentifier string
+	Name               string
+	ContentType        string
+	Product            string
+	Version            string
+	Address            string
+	Port               int
+	UpdatedAt          int64
+}
+
+// Uri is the address the device's http endpoint listens on.
+func (device *GdmDevice) Uri() string {
+	return "http://" + net.JoinHostPort(device.Address, strconv.Itoa(device.Port))
+}
+
+// Server returns a PlexServer for a discovered media server. A local
+// server may accept requests without a token, depending on its settings.
+func (device *GdmDevice) Server(token string) *PlexServer {
+	server := NewPlexServer(device.Uri(), token)
+	server.MachineIdentifier = device.ResourceIdentifier
+	return server
+}
+
+// DiscoverServers looks for media servers on the local network, collecting
+// answers until timeout elapses or ctx is done. No plex.tv account is
+// needed.
+func DiscoverServers(ctx context.Context, timeout time.Duration) ([]*GdmDevice, error) {
+	return gdmDiscover(ctx, timeout, gdmServerPort)
+}
+
+// DiscoverPlayers looks for players (Plex clients) on the local network.
+func DiscoverPlayers(ctx context.Context, timeout time.Duration) ([]*GdmDevice, error) {
+	return gdmDiscover(ctx, timeout, gdmPlayerPort)
+}
+
+func gdmDiscover(ctx context.Context, timeout time.Duration, port int) ([]*GdmDevice, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	// unblock the read below as soon as ctx is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	target := &net.UDPAddr{IP: net.ParseIP(gdmMulticastAddress), Port: port}
+	_, err = conn.WriteTo(gdmSearch, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []*GdmDevice
+	seen := make(map[string]bool)
+	buffer := make([]byte, 4096)
+	for {
+		n, addr, err := conn.ReadFrom(buffer)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			return devices, err
+		}
+
+		device, err := parseGdmResponse(buffer[:n])
+		if err != nil {
+			// not everything on the group speaks GDM properly
+			continue
+		}
+		if udpAddr, ok := addr.(*net.UDPAddr); ok {
+			device.Address = udpAddr.IP.String()
+		}
+
+		if seen[device.ResourceIdentifier] {
+			continue
+		}
+		seen[device.ResourceIdentifier] = true
+		devices = append(devices, device)
+	}
+
+	if ctx.Err() != nil && len(devices) == 0 {
+		return nil, ctx.Err()
+	}
+	return devices, nil
+}
+
+func parseGdmResponse(data []byte) (*GdmDevice, error) {
+	// servers don't always end the headers with an empty line
+	data = append(data, "\r\n\r\n"...)
+
+	response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), nil)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gdm: unexpected status %q", response.Status)
+	}
+
+	device := &GdmDevice{
+		ResourceIdentifier: response.Header.Get("Resource-Identifier"),
+		Name:               response.Header.Get("Name"),
+		ContentType:        response.Header.Get("Content-Type"),
+		Product:            response.Header.Get("Product"),
+		Version:            response.Header.Get("Version"),
+	}
+	if len(device.ResourceIdentifier) == 0 {
+		return nil, errors.New("gdm: response without Resource-Identifier")
+	}
+
+	device.Port, _ = strconv.Atoi(response.Header.Get("Port"))
+	device.UpdatedAt, _ = strconv.ParseInt(response.Header.Get("Updated-At"), 10, 64)
+
+	return device, nil
+}
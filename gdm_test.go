@@ -0,0 +1,69 @@
+package goplex
+
+import (
+	"testing"
+)
+
+func TestParseGdmResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+	}{
+		{"terminated headers", "HTTP/1.0 200 OK\r\n" +
+			"Content-Type: plex/media-server\r\n" +
+			"Resource-Identifier: 0123456789abcdef\r\n" +
+			"Name: Basement\r\n" +
+			"Port: 32400\r\n" +
+			"Updated-At: 1700000000\r\n" +
+			"Version: 1.40.0.7998\r\n" +
+			"\r\n"},
+		{"unterminated headers", "HTTP/1.0 200 OK\r\n" +
+			"Content-Type: plex/media-server\r\n" +
+			"Resource-Identifier: 0123456789abcdef\r\n" +
+			"Name: Basement\r\n" +
+			"Port: 32400\r\n" +
+			"Updated-At: 1700000000\r\n" +
+			"Version: 1.40.0.7998"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			device, err := parseGdmResponse([]byte(test.response))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := GdmDevice{
+				ResourceIdentifier: "0123456789abcdef",
+				Name:               "Basement",
+				ContentType:        "plex/media-server",
+				Version:            "1.40.0.7998",
+				Port:               32400,
+				UpdatedAt:          1700000000,
+			}
+			if *device != want {
+				t.Errorf("got %+v, want %+v", *device, want)
+			}
+		})
+	}
+}
+
+func TestParseGdmResponseInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+	}{
+		{"not http", "M-SEARCH * HTTP/1.1"},
+		{"not ok", "HTTP/1.0 404 Not Found\r\nResource-Identifier: abc\r\n"},
+		{"no identifier", "HTTP/1.0 200 OK\r\nName: Basement\r\nPort: 32400\r\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			device, err := parseGdmResponse([]byte(test.response))
+			if err == nil {
+				t.Errorf("got %+v, want an error", device)
+			}
+		})
+	}
+}
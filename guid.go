@@ -0,0 +1,48 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+)
+
+// ratingKeys collects the rating keys of everything in the container.
+func (container *MediaContainer) ratingKeys() []string {
+	var ratingKeys []string
+	for _, directory := range container.Directories {
+		ratingKeys = append(ratingKeys, directory.RatingKey)
+	}
+	for _, video := range container.Videos {
+		ratingKeys = append(ratingKeys, video.RatingKey)
+	}
+	for _, track := range container.Tracks {
+		ratingKeys = append(ratingKeys, track.RatingKey)
+	}
+	return ratingKeys
+}
+
+// FindByGuid returns the rating keys of the items in any library with the
+// given guid, either a Plex guid (plex://movie/5d7768...) or an external
+// one such as imdb://tt0133093, tmdb://603 or tvdb://169. It answers "is
+// this already in my library?" without listing the libraries.
+func (server *PlexServer) FindByGuid(ctx context.Context, guid string) ([]string, error) {
+	params := url.Values{}
+	params.Set("guid", guid)
+
+	var container MediaContainer
+	err := server.get(ctx, "/library/all", params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.ratingKeys(), nil
+}
+
+// FindByGuid is like PlexServer.FindByGuid, limited to the section.
+func (section *Section) FindByGuid(ctx context.Context, guid string) ([]string, error) {
+	container, err := section.All(ctx, NewFilter().Where("guid", OpIs, guid), 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.ratingKeys(), nil
+}
@@ -0,0 +1,74 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// HistoryOptions narrows down History. Zero values don't filter.
+type HistoryOptions struct {
+	AccountId        int
+	LibrarySectionId string
+	Since            time.Time
+	Until            time.Time
+
+	// Start and Size page through the history, newest first. Size 0 lets
+	// the server decide.
+	Start int
+	Size  int
+}
+
+// HistoryEntry is one time an item was played.
+type HistoryEntry struct {
+	HistoryKey       string `xml:"historyKey,attr" json:"historyKey"`
+	Key              string `xml:"key,attr" json:"key"`
+	RatingKey        string `xml:"ratingKey,attr" json:"ratingKey"`
+	LibrarySectionId string `xml:"librarySectionID,attr" json:"librarySectionID"`
+	Type             string `xml:"type,attr" json:"type"`
+	Title            string `xml:"title,attr" json:"title"`
+	ParentTitle      string `xml:"parentTitle,attr" json:"parentTitle"`
+	GrandparentTitle string `xml:"grandparentTitle,attr" json:"grandparentTitle"`
+	Index            int    `xml:"index,attr" json:"index"`
+	ParentIndex      int    `xml:"parentIndex,attr" json:"parentIndex"`
+	Thumb            string `xml:"thumb,attr" json:"thumb"`
+	ViewedAt         int64  `xml:"viewedAt,attr" json:"viewedAt"`
+	AccountId        int    `xml:"accountID,attr" json:"accountID"`
+	DeviceId         int    `xml:"deviceID,attr" json:"deviceID"`
+}
+
+type historyContainer struct {
+	TotalSize int `xml:"totalSize,attr" json:"totalSize"`
+
+	// videos, tracks and photos, in the order the server sorted them
+	Entries []*HistoryEntry `xml:",any" json:"Metadata"`
+}
+
+// History returns a page of the server's watch history, newest first,
+// along with the total number of entries matching the options.
+func (server *PlexServer) History(ctx context.Context, options HistoryOptions) ([]*HistoryEntry, int, error) {
+	params := url.Values{}
+	params.Set("sort", "viewedAt:desc")
+	if options.AccountId != 0 {
+		params.Set("accountID", strconv.Itoa(options.AccountId))
+	}
+	if len(options.LibrarySectionId) > 0 {
+		params.Set("librarySectionID", options.LibrarySectionId)
+	}
+	if !options.Since.IsZero() {
+		params.Set("viewedAt>", strconv.FormatInt(options.Since.Unix(), 10))
+	}
+	if !options.Until.IsZero() {
+		params.Set("viewedAt<", strconv.FormatInt(options.Until.Unix(), 10))
+	}
+	setPaging(params, options.Start, options.Size)
+
+	var container historyContainer
+	err := server.get(ctx, "/status/sessions/history/all", params, &container)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return container.Entries, container.TotalSize, nil
+}
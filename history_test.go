@@ -0,0 +1,34 @@
+package goplex
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHistoryDateRange(t *testing.T) {
+	recorder, server := newRecordingServer(t, "application/xml", `<MediaContainer totalSize="0"></MediaContainer>`)
+
+	_, _, err := server.History(context.Background(), HistoryOptions{
+		Since: time.Unix(1700000000, 0),
+		Until: time.Unix(1700086400, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := recorder.last(t)
+	if request.URL.Path != "/status/sessions/history/all" {
+		t.Errorf("requested %s", request.URL.Path)
+	}
+
+	// the operator goes in the key: viewedAt>=1700000000 on the wire
+	want := "sort=viewedAt%3Adesc&viewedAt%3C=1700086400&viewedAt%3E=1700000000"
+	if request.URL.RawQuery != want {
+		t.Errorf("query %q, want %q", request.URL.RawQuery, want)
+	}
+	query := request.URL.Query()
+	if query.Get("viewedAt>") != "1700000000" || query.Get("viewedAt<") != "1700086400" {
+		t.Errorf("query %v has no date range", query)
+	}
+}
@@ -0,0 +1,100 @@
+package goplex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// HomeUser is a member of the account's Plex Home.
+type HomeUser struct {
+	Id           int    `xml:"id,attr"`
+	Uuid         string `xml:"uuid,attr"`
+	Title        string `xml:"title,attr"`
+	Username     string `xml:"username,attr"`
+	Email        string `xml:"email,attr"`
+	Thumb        string `xml:"thumb,attr"`
+	IsAdmin      bool   `xml:"admin,attr"`
+	IsGuest      bool   `xml:"guest,attr"`
+	IsRestricted bool   `xml:"restricted,attr"`
+
+	// IsProtected is set when switching to the user requires their PIN.
+	IsProtected bool `xml:"protected,attr"`
+}
+
+type homeUserContainer struct {
+	Users []*HomeUser `xml:"User"`
+}
+
+// HomeUsers lists the users in the account's Plex Home, including the
+// account itself.
+func (user *UserAuthQuery) HomeUsers(ctx context.Context) ([]*HomeUser, error) {
+	client := clientOrDefault(user.client)
+
+	request, err := client.newPlexRequest(
+		ctx,
+		"GET",
+		"https://plex.tv/api/home/users",
+		user.AuthToken,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.getResponse(request, http.StatusOK)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var q homeUserContainer
+	err = unmarshalResponse(response, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Users, nil
+}
+
+// SwitchHomeUser signs in as another member of the Plex Home, e.g. a managed
+// user, returning their account and token. pin is only needed for protected
+// users.
+func (user *UserAuthQuery) SwitchHomeUser(ctx context.Context, homeUserId int, pin string) (*UserAuthQuery, error) {
+	client := clientOrDefault(user.client)
+
+	uri := fmt.Sprintf("https://plex.tv/api/home/users/%d/switch", homeUserId)
+	if len(pin) > 0 {
+		uri += "?pin=" + url.QueryEscape(pin)
+	}
+
+	request, err := client.newPlexRequest(
+		ctx,
+		"POST",
+		uri,
+		user.AuthToken,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.getResponse(request, http.StatusOK, http.StatusCreated)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	q := UserAuthQuery{client: client}
+	err = unmarshalResponse(response, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &q, nil
+}
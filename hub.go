@@ -0,0 +1,160 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Hub is a titled row of items, e.g. the "Movies" results of a search or
+// "Recently Added" on the home screen.
+type Hub struct {
+	HubIdentifier string `xml:"hubIdentifier,attr" json:"hubIdentifier"`
+	Key           string `xml:"key,attr" json:"key"`
+	Type          string `xml:"type,attr" json:"type"`
+	Title         string `xml:"title,attr" json:"title"`
+	Size          int    `xml:"size,attr" json:"size"`
+	More          Bool   `xml:"more,attr" json:"more"`
+
+	Directories []*Directory `xml:"Directory" json:"Directory"`
+	Videos      []*Video     `xml:"Video" json:"Metadata"`
+	Tracks      []*Track     `xml:"Track" json:"-"`
+}
+
+type hubContainer struct {
+	Hubs []*Hub `xml:"Hub" json:"Hub"`
+}
+
+// Search looks for query across every library on the server. Results are
+// grouped into one hub per type (movies, shows, episodes, artists...),
+// each with at most limit items. A limit of 0 lets the server decide.
+func (server *PlexServer) Search(ctx context.Context, query string, limit int) ([]*Hub, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	var container hubContainer
+	err := server.get(ctx, "/hubs/search", params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Hubs, nil
+}
+
+// SearchOptions narrows down Section.Search.
+type SearchOptions struct {
+	// Type is one of the MediaType constants, e.g. MediaTypeEpisode to
+	// search a TV section's episodes rather than its shows.
+	Type int
+
+	// Filters are extra query parameters, e.g. "year" or "unwatched".
+	Filters url.Values
+
+	// Start and Size page through the results. Size 0 lets the server
+	// decide.
+	Start int
+	Size  int
+}
+
+// Search looks for query in the section's titles.
+func (section *Section) Search(ctx context.Context, query string, options SearchOptions) (*MediaContainer, error) {
+	params := url.Values{}
+	for key, values := range options.Filters {
+		params[key] = values
+	}
+	params.Set("query", query)
+	if options.Type != 0 {
+		params.Set("type", strconv.Itoa(options.Type))
+	}
+	setPaging(params, options.Start, options.Size)
+
+	var container MediaContainer
+	err := section.server.get(ctx, section.path("/search"), params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
+
+func (server *PlexServer) hubs(ctx context.Context, path string) ([]*Hub, error) {
+	var container hubContainer
+	err := server.get(ctx, path, nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Hubs, nil
+}
+
+// Hubs returns the rows of the server's home screen: continue watching,
+// on deck, recently added per library and so on.
+func (server *PlexServer) Hubs(ctx context.Context) ([]*Hub, error) {
+	return server.hubs(ctx, "/hubs")
+}
+
+// Hubs returns the rows shown at the top of the section in Plex apps.
+func (section *Section) Hubs(ctx context.Context) ([]*Hub, error) {
+	return section.server.hubs(ctx, "/hubs/sections/"+url.PathEscape(section.Key))
+}
+
+// ContinueWatching returns the partly watched items and next episodes,
+// across all libraries, as the home screen shows them.
+func (server *PlexServer) ContinueWatching(ctx context.Context) ([]*Hub, error) {
+	return server.hubs(ctx, "/hubs/continueWatching")
+}
+
+// OnDeck lists partly watched items and the next episode of shows being
+// watched, across all libraries.
+func (server *PlexServer) OnDeck(ctx context.Context) (*MediaContainer, error) {
+	var container MediaContainer
+	err := server.get(ctx, "/library/onDeck", nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
+
+// OnDeck is like PlexServer.OnDeck, limited to the section.
+func (section *Section) OnDeck(ctx context.Context) (*MediaContainer, error) {
+	var container MediaContainer
+	err := section.server.get(ctx, section.path("/onDeck"), nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
+
+// RecentlyAdded lists the newest items across all libraries, starting at
+// start. A size of 0 lets the server decide how many to return.
+func (server *PlexServer) RecentlyAdded(ctx context.Context, start, size int) (*MediaContainer, error) {
+	params := url.Values{}
+	setPaging(params, start, size)
+
+	var container MediaContainer
+	err := server.get(ctx, "/library/recentlyAdded", params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
+
+// RecentlyAdded is like PlexServer.RecentlyAdded, limited to the section.
+func (section *Section) RecentlyAdded(ctx context.Context, start, size int) (*MediaContainer, error) {
+	params := url.Values{}
+	setPaging(params, start, size)
+
+	var container MediaContainer
+	err := section.server.get(ctx, section.path("/recentlyAdded"), params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
@@ -0,0 +1,80 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Invite is a pending friend, home or server share invite, either sent by
+// the account or to it.
+type Invite struct {
+	Id           int    `xml:"id,attr"`
+	CreatedAt    int64  `xml:"createdAt,attr"`
+	Username     string `xml:"username,attr"`
+	Email        string `xml:"email,attr"`
+	Thumb        string `xml:"thumb,attr"`
+	FriendlyName string `xml:"friendlyName,attr"`
+	IsFriend     bool   `xml:"friend,attr"`
+	IsHome       bool   `xml:"home,attr"`
+	IsServer     bool   `xml:"server,attr"`
+
+	Servers []*InviteServer `xml:"Server"`
+}
+
+// InviteServer is a server an invite shares.
+type InviteServer struct {
+	Name         string `xml:"name,attr"`
+	NumLibraries int    `xml:"numLibraries,attr"`
+}
+
+const (
+	receivedInvitesUri = "https://plex.tv/api/invites/requests"
+	sentInvitesUri     = "https://plex.tv/api/invites/requested"
+)
+
+func (user *UserAuthQuery) invites(ctx context.Context, uri string) ([]*Invite, error) {
+	var q struct {
+		Invites []*Invite `xml:"Invite"`
+	}
+	err := user.plexTv(ctx, "GET", uri, nil, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Invites, nil
+}
+
+// ReceivedInvites lists invites other users sent the account.
+func (user *UserAuthQuery) ReceivedInvites(ctx context.Context) ([]*Invite, error) {
+	return user.invites(ctx, receivedInvitesUri)
+}
+
+// SentInvites lists the account's invites that haven't been answered yet.
+func (user *UserAuthQuery) SentInvites(ctx context.Context) ([]*Invite, error) {
+	return user.invites(ctx, sentInvitesUri)
+}
+
+func (user *UserAuthQuery) answerInvite(ctx context.Context, method, uri string, invite *Invite) error {
+	params := url.Values{}
+	params.Set("friend", boolParam(invite.IsFriend))
+	params.Set("home", boolParam(invite.IsHome))
+	params.Set("server", boolParam(invite.IsServer))
+
+	return user.plexTv(ctx, method, uri+"/"+strconv.Itoa(invite.Id)+"?"+params.Encode(), nil, nil)
+}
+
+// AcceptInvite accepts one of the ReceivedInvites.
+func (user *UserAuthQuery) AcceptInvite(ctx context.Context, invite *Invite) error {
+	return user.answerInvite(ctx, "PUT", receivedInvitesUri, invite)
+}
+
+// RejectInvite declines one of the ReceivedInvites.
+func (user *UserAuthQuery) RejectInvite(ctx context.Context, invite *Invite) error {
+	return user.answerInvite(ctx, "DELETE", receivedInvitesUri, invite)
+}
+
+// CancelInvite withdraws one of the SentInvites.
+func (user *UserAuthQuery) CancelInvite(ctx context.Context, invite *Invite) error {
+	return user.answerInvite(ctx, "DELETE", sentInvitesUri, invite)
+}
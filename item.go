@@ -0,0 +1,102 @@
+package goplex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Item is a handle on one library item (movie, show, season, episode,
+// artist, album, track...) for acting on it. It is cheap to create and
+// doesn't contact the server by itself.
+type Item struct {
+	RatingKey string
+
+	server *PlexServer
+}
+
+// Item returns a handle on the item with the given rating key.
+func (server *PlexServer) Item(ratingKey string) *Item {
+	return &Item{RatingKey: ratingKey, server: server}
+}
+
+func (item *Item) path(suffix string) string {
+	return "/library/metadata/" + url.PathEscape(item.RatingKey) + suffix
+}
+
+// Refresh re-downloads the item's metadata, and that of its children.
+func (item *Item) Refresh(ctx context.Context) error {
+	return item.server.action(ctx, "PUT", item.path("/refresh"), nil)
+}
+
+// the plugin identifier /:/ endpoints need for library items
+const libraryIdentifier = "com.plexapp.plugins.library"
+
+func (item *Item) scrobble(ctx context.Context, path string) error {
+	params := url.Values{}
+	params.Set("key", item.RatingKey)
+	params.Set("identifier", libraryIdentifier)
+
+	return item.server.action(ctx, "GET", path, params)
+}
+
+// MarkWatched marks the item as played. For a show, season, artist or
+// album this marks everything in it.
+func (item *Item) MarkWatched(ctx context.Context) error {
+	return item.scrobble(ctx, "/:/scrobble")
+}
+
+// MarkUnwatched marks the item, or everything in it, as not played and
+// clears its resume point.
+func (item *Item) MarkUnwatched(ctx context.Context) error {
+	return item.scrobble(ctx, "/:/unscrobble")
+}
+
+// MarkWatched marks several items as played, stopping at the first
+// failure.
+func (server *PlexServer) MarkWatched(ctx context.Context, ratingKeys ...string) error {
+	for _, ratingKey := range ratingKeys {
+		err := server.Item(ratingKey).MarkWatched(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkUnwatched marks several items as not played, stopping at the first
+// failure.
+func (server *PlexServer) MarkUnwatched(ctx context.Context, ratingKeys ...string) error {
+	for _, ratingKey := range ratingKeys {
+		err := server.Item(ratingKey).MarkUnwatched(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rate sets the user's star rating on a 0 to 10 scale, where each star is
+// worth 2.
+func (item *Item) Rate(ctx context.Context, rating float64) error {
+	if rating < 0 || rating > 10 {
+		return fmt.Errorf("rating %v is outside 0-10", rating)
+	}
+
+	return item.rate(ctx, strconv.FormatFloat(rating, 'f', -1, 64))
+}
+
+// ClearRating removes the user's rating from the item.
+func (item *Item) ClearRating(ctx context.Context) error {
+	return item.rate(ctx, "-1")
+}
+
+func (item *Item) rate(ctx context.Context, rating string) error {
+	params := url.Values{}
+	params.Set("key", item.RatingKey)
+	params.Set("identifier", libraryIdentifier)
+	params.Set("rating", rating)
+
+	return item.server.action(ctx, "PUT", "/:/rate", params)
+}
@@ -0,0 +1,124 @@
+package goplex
+
+import (
+	"context"
+)
+
+// DefaultPageSize is the number of items an Iterator fetches per request
+// when not told otherwise.
+const DefaultPageSize = 200
+
+// Iterator walks through a large listing one item at a time, fetching it
+// from the server a page at a time:
+//
+//	iterator := section.Iterate(nil, 0)
+//	for iterator.Next(ctx) {
+//		if video := iterator.Video(); video != nil {
+//			...
+//		}
+//	}
+//	if err := iterator.Err(); err != nil {
+//		...
+//	}
+//
+// Items added or removed while iterating can be skipped or seen twice.
+type Iterator struct {
+	fetch    func(ctx context.Context, start, size int) (*MediaContainer, error)
+	pageSize int
+
+	start     int
+	totalSize int
+	done      bool
+	err       error
+
+	directories []*Directory
+	videos      []*Video
+	directory   *Directory
+	video       *Video
+}
+
+// Iterate returns an Iterator over the section's items matching filter,
+// which may be nil. A pageSize of 0 uses DefaultPageSize.
+func (section *Section) Iterate(filter *Filter, pageSize int) *Iterator {
+	return newIterator(pageSize, func(ctx context.Context, start, size int) (*MediaContainer, error) {
+		return section.All(ctx, filter, start, size)
+	})
+}
+
+func newIterator(pageSize int, fetch func(ctx context.Context, start, size int) (*MediaContainer, error)) *Iterator {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Iterator{fetch: fetch, pageSize: pageSize, totalSize: -1}
+}
+
+// Next moves on to the next item, fetching another page when needed. It
+// returns false once there are no more items or a request failed.
+func (iterator *Iterator) Next(ctx context.Context) bool {
+	iterator.directory, iterator.video = nil, nil
+
+	if len(iterator.directories) == 0 && len(iterator.videos) == 0 {
+		if iterator.done || iterator.err != nil {
+			return false
+		}
+		iterator.nextPage(ctx)
+	}
+
+	if len(iterator.directories) > 0 {
+		iterator.directory = iterator.directories[0]
+		iterator.directories = iterator.directories[1:]
+		return true
+	}
+	if len(iterator.videos) > 0 {
+		iterator.video = iterator.videos[0]
+		iterator.videos = iterator.videos[1:]
+		return true
+	}
+	return false
+}
+
+func (iterator *Iterator) nextPage(ctx context.Context) {
+	container, err := iterator.fetch(ctx, iterator.start, iterator.pageSize)
+	if err != nil {
+		iterator.err = err
+		return
+	}
+
+	iterator.directories = container.Directories
+	iterator.videos = append(container.Videos, container.Tracks...)
+	count := len(iterator.directories) + len(iterator.videos)
+	iterator.start += count
+	iterator.totalSize = container.TotalSize
+
+	// servers may cap the page size below what was asked for, so a short
+	// page only means the end when there is no total size to go by
+	switch {
+	case count == 0:
+		iterator.done = true
+	case container.TotalSize > 0:
+		iterator.done = iterator.start >= container.TotalSize
+	default:
+		iterator.done = count < iterator.pageSize
+	}
+}
+
+// Directory is the current item if it is a show, season, artist or album.
+func (iterator *Iterator) Directory() *Directory {
+	return iterator.directory
+}
+
+// Video is the current item if it is a movie, episode or track.
+func (iterator *Iterator) Video() *Video {
+	return iterator.video
+}
+
+// TotalSize is the number of items the server reported in total, or -1
+// before the first page has been fetched.
+func (iterator *Iterator) TotalSize() int {
+	return iterator.totalSize
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (iterator *Iterator) Err() error {
+	return iterator.err
+}
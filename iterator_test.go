@@ -0,0 +1,85 @@
+package goplex
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+// fakeListing serves total items, never more than maxPage at a time. When
+// reportTotal is false the containers leave TotalSize out.
+func fakeListing(total, maxPage int, reportTotal bool, requests *int) func(ctx context.Context, start, size int) (*MediaContainer, error) {
+	return func(ctx context.Context, start, size int) (*MediaContainer, error) {
+		*requests++
+		if size > maxPage {
+			size = maxPage
+		}
+
+		container := &MediaContainer{}
+		if reportTotal {
+			container.TotalSize = total
+		}
+		for i := start; i < start+size && i < total; i++ {
+			container.Videos = append(container.Videos, &Video{RatingKey: strconv.Itoa(i)})
+		}
+		return container, nil
+	}
+}
+
+func countItems(t *testing.T, iterator *Iterator) int {
+	count := 0
+	for iterator.Next(context.Background()) {
+		if iterator.Video() == nil {
+			t.Fatal("expected a video")
+		}
+		count++
+	}
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestIteratorCappedPageSize(t *testing.T) {
+	// the server hands out 50 items per page however many are asked for
+	var requests int
+	iterator := newIterator(200, fakeListing(120, 50, true, &requests))
+
+	if count := countItems(t, iterator); count != 120 {
+		t.Errorf("got %d items, want 120", count)
+	}
+	if requests != 3 {
+		t.Errorf("made %d requests, want 3", requests)
+	}
+	if iterator.TotalSize() != 120 {
+		t.Errorf("TotalSize() = %d, want 120", iterator.TotalSize())
+	}
+}
+
+func TestIteratorWithoutTotalSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		requests int
+	}{
+		// the short third page ends the iteration
+		{"short last page", 45, 3},
+		// the last full page is followed by an empty one
+		{"empty last page", 40, 3},
+		{"empty listing", 0, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests int
+			iterator := newIterator(20, fakeListing(test.total, 20, false, &requests))
+
+			if count := countItems(t, iterator); count != test.total {
+				t.Errorf("got %d items, want %d", count, test.total)
+			}
+			if requests != test.requests {
+				t.Errorf("made %d requests, want %d", requests, test.requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package goplex
+
+// MediaContainer is the root of (almost) every media server response.
+//
+// In JSON responses movies, episodes, tracks, shows and seasons all come
+// back as "Metadata"; shows, seasons, artists and albums are decoded into
+// Directories and the rest into Videos, as in XML responses.
+type MediaContainer struct {
+	Size                int    `xml:"size,attr" json:"size"`
+	TotalSize           int    `xml:"totalSize,attr" json:"totalSize"`
+	Offset              int    `xml:"offset,attr" json:"offset"`
+	Title1              string `xml:"title1,attr" json:"title1"`
+	Title2              string `xml:"title2,attr" json:"title2"`
+	Identifier          string `xml:"identifier,attr" json:"identifier"`
+	MachineIdentifier   string `xml:"machineIdentifier,attr" json:"machineIdentifier"`
+	LibrarySectionID    int    `xml:"librarySectionID,attr" json:"librarySectionID"`
+	LibrarySectionTitle string `xml:"librarySectionTitle,attr" json:"librarySectionTitle"`
+	ViewGroup           string `xml:"viewGroup,attr" json:"viewGroup"`
+
+	Directories []*Directory `xml:"Directory" json:"Directory"`
+	Videos      []*Video     `xml:"Video" json:"Metadata"`
+	Tracks      []*Track     `xml:"Track" json:"-"`
+}
+
+// Directory is anything that contains other items: a library section, a
+// show, a season, an artist or an album.
+type Directory struct {
+	Key                   string  `xml:"key,attr" json:"key"`
+	RatingKey             string  `xml:"ratingKey,attr" json:"ratingKey"`
+	Guid                  string  `xml:"guid,attr" json:"guid"`
+	Type                  string  `xml:"type,attr" json:"type"`
+	Title                 string  `xml:"title,attr" json:"title"`
+	TitleSort             string  `xml:"titleSort,attr" json:"titleSort"`
+	Summary               string  `xml:"summary,attr" json:"summary"`
+	Index                 int     `xml:"index,attr" json:"index"`
+	Year                  int     `xml:"year,attr" json:"year"`
+	Thumb                 string  `xml:"thumb,attr" json:"thumb"`
+	Art                   string  `xml:"art,attr" json:"art"`
+	ParentRatingKey       string  `xml:"parentRatingKey,attr" json:"parentRatingKey"`
+	ParentTitle           string  `xml:"parentTitle,attr" json:"parentTitle"`
+	LeafCount             int     `xml:"leafCount,attr" json:"leafCount"`
+	ViewedLeafCount       int     `xml:"viewedLeafCount,attr" json:"viewedLeafCount"`
+	ChildCount            int     `xml:"childCount,attr" json:"childCount"`
+	ContentRating         string  `xml:"contentRating,attr" json:"contentRating"`
+	Rating                float64 `xml:"rating,attr" json:"rating"`
+	AudienceRating        float64 `xml:"audienceRating,attr" json:"audienceRating"`
+	UserRating            float64 `xml:"userRating,attr" json:"userRating"`
+	Studio                string  `xml:"studio,attr" json:"studio"`
+	OriginallyAvailableAt string  `xml:"originallyAvailableAt,attr" json:"originallyAvailableAt"`
+	AddedAt               int64   `xml:"addedAt,attr" json:"addedAt"`
+	UpdatedAt             int64   `xml:"updatedAt,attr" json:"updatedAt"`
+	DeletedAt             int64   `xml:"deletedAt,attr" json:"deletedAt"`
+
+	// library sections only
+	Agent     string      `xml:"agent,attr" json:"agent"`
+	Scanner   string      `xml:"scanner,attr" json:"scanner"`
+	Language  string      `xml:"language,attr" json:"language"`
+	Uuid      string      `xml:"uuid,attr" json:"uuid"`
+	ScannedAt int64       `xml:"scannedAt,attr" json:"scannedAt"`
+	CreatedAt int64       `xml:"createdAt,attr" json:"createdAt"`
+	Locations []*Location `xml:"Location" json:"Location"`
+
+	Genres []*Tag `xml:"Genre" json:"Genre"`
+	Labels []*Tag `xml:"Label" json:"Label"`
+	Roles  []*Tag `xml:"Role" json:"Role"`
+
+	// Guids are the item's ids at IMDb, TMDB, TVDB and so on.
+	Guids []*ExternalGuid `xml:"Guid" json:"Guid"`
+}
+
+// Location is a folder a library section reads its media from.
+type Location struct {
+	Id   int    `xml:"id,attr" json:"id"`
+	Path string `xml:"path,attr" json:"path"`
+}
+
+// Video is a movie, episode or clip.
+type Video struct {
+	Key                   string  `xml:"key,attr" json:"key"`
+	RatingKey             string  `xml:"ratingKey,attr" json:"ratingKey"`
+	Guid                  string  `xml:"guid,attr" json:"guid"`
+	Type                  string  `xml:"type,attr" json:"type"`
+	Title                 string  `xml:"title,attr" json:"title"`
+	TitleSort             string  `xml:"titleSort,attr" json:"titleSort"`
+	Summary               string  `xml:"summary,attr" json:"summary"`
+	Tagline               string  `xml:"tagline,attr" json:"tagline"`
+	Year                  int     `xml:"year,attr" json:"year"`
+	Index                 int     `xml:"index,attr" json:"index"`
+	ParentIndex           int     `xml:"parentIndex,attr" json:"parentIndex"`
+	ParentRatingKey       string  `xml:"parentRatingKey,attr" json:"parentRatingKey"`
+	ParentTitle           string  `xml:"parentTitle,attr" json:"parentTitle"`
+	GrandparentRatingKey  string  `xml:"grandparentRatingKey,attr" json:"grandparentRatingKey"`
+	GrandparentTitle      string  `xml:"grandparentTitle,attr" json:"grandparentTitle"`
+	LibrarySectionID      int     `xml:"librarySectionID,attr" json:"librarySectionID"`
+	LibrarySectionTitle   string  `xml:"librarySectionTitle,attr" json:"librarySectionTitle"`
+	ContentRating         string  `xml:"contentRating,attr" json:"contentRating"`
+	Rating                float64 `xml:"rating,attr" json:"rating"`
+	AudienceRating        float64 `xml:"audienceRating,attr" json:"audienceRating"`
+	UserRating            float64 `xml:"userRating,attr" json:"userRating"`
+	Studio                string  `xml:"studio,attr" json:"studio"`
+	Duration              int64   `xml:"duration,attr" json:"duration"`
+	ViewOffset            int64   `xml:"viewOffset,attr" json:"viewOffset"`
+	ViewCount             int     `xml:"viewCount,attr" json:"viewCount"`
+	LastViewedAt          int64   `xml:"lastViewedAt,attr" json:"lastViewedAt"`
+	OriginallyAvailableAt string  `xml:"originallyAvailableAt,attr" json:"originallyAvailableAt"`
+	AddedAt               int64   `xml:"addedAt,attr" json:"addedAt"`
+	UpdatedAt             int64   `xml:"updatedAt,attr" json:"updatedAt"`
+	DeletedAt             int64   `xml:"deletedAt,attr" json:"deletedAt"`
+	Thumb                 string  `xml:"thumb,attr" json:"thumb"`
+	Art                   string  `xml:"art,attr" json:"art"`
+
+	// only set on items in a play queue or playlist
+	PlayQueueItemId int `xml:"playQueueItemID,attr" json:"playQueueItemID"`
+	PlaylistItemId  int `xml:"playlistItemID,attr" json:"playlistItemID"`
+
+	// only set on items listed by Sessions
+	SessionKey       string            `xml:"sessionKey,attr" json:"sessionKey"`
+	User             *SessionUser      `xml:"User" json:"User"`
+	Player           *SessionPlayer    `xml:"Player" json:"Player"`
+	Session          *PlaybackSession  `xml:"Session" json:"Session"`
+	TranscodeSession *TranscodeSession `xml:"TranscodeSession" json:"TranscodeSession"`
+
+	Media     []*Media `xml:"Media" json:"Media"`
+	Genres    []*Tag   `xml:"Genre" json:"Genre"`
+	Directors []*Tag   `xml:"Director" json:"Director"`
+	Writers   []*Tag   `xml:"Writer" json:"Writer"`
+	Roles     []*Tag   `xml:"Role" json:"Role"`
+	Countries []*Tag   `xml:"Country" json:"Country"`
+	Labels    []*Tag   `xml:"Label" json:"Label"`
+
+	// Guids are the item's ids at IMDb, TMDB, TVDB and so on.
+	Guids []*ExternalGuid `xml:"Guid" json:"Guid"`
+
+	// only set when fetched with MetadataWithOptions
+	Chapters []*Chapter `xml:"Chapter" json:"Chapter"`
+	Markers  []*Marker  `xml:"Marker" json:"Marker"`
+}
+
+// Track is a music track. Tracks carry the same attributes as videos, with
+// the album in Parent* and the artist in Grandparent*.
+type Track = Video
+
+// Tag is a genre, label, actor, director and so on.
+type Tag struct {
+	Id    int    `xml:"id,attr" json:"id"`
+	Tag   string `xml:"tag,attr" json:"tag"`
+	Role  string `xml:"role,attr" json:"role"`
+	Thumb string `xml:"thumb,attr" json:"thumb"`
+}
+
+// ExternalGuid is an item's id in another database, e.g. imdb://tt0133093,
+// tmdb://603 or tvdb://169.
+type ExternalGuid struct {
+	Id string `xml:"id,attr" json:"id"`
+}
+
+// Media is one version of an item, e.g. the 4K and the 1080p copy of the
+// same movie.
+type Media struct {
+	Id                    int     `xml:"id,attr" json:"id"`
+	Duration              int64   `xml:"duration,attr" json:"duration"`
+	Bitrate               int     `xml:"bitrate,attr" json:"bitrate"`
+	Width                 int     `xml:"width,attr" json:"width"`
+	Height                int     `xml:"height,attr" json:"height"`
+	AspectRatio           float64 `xml:"aspectRatio,attr" json:"aspectRatio"`
+	AudioChannels         int     `xml:"audioChannels,attr" json:"audioChannels"`
+	AudioCodec            string  `xml:"audioCodec,attr" json:"audioCodec"`
+	VideoCodec            string  `xml:"videoCodec,attr" json:"videoCodec"`
+	VideoResolution       string  `xml:"videoResolution,attr" json:"videoResolution"`
+	VideoFrameRate        string  `xml:"videoFrameRate,attr" json:"videoFrameRate"`
+	Container             string  `xml:"container,attr" json:"container"`
+	OptimizedForStreaming Bool    `xml:"optimizedForStreaming,attr" json:"optimizedForStreaming"`
+
+	Parts []*Part `xml:"Part" json:"Part"`
+}
+
+// Part is a single file making up a Media.
+type Part struct {
+	Id        int    `xml:"id,attr" json:"id"`
+	Key       string `xml:"key,attr" json:"key"`
+	Duration  int64  `xml:"duration,attr" json:"duration"`
+	File      string `xml:"file,attr" json:"file"`
+	Size      int64  `xml:"size,attr" json:"size"`
+	Container string `xml:"container,attr" json:"container"`
+
+	Streams []*Stream `xml:"Stream" json:"Stream"`
+}
+
+// Metadata types, as used by the type parameter of library queries.
+const (
+	MediaTypeMovie   = 1
+	MediaTypeShow    = 2
+	MediaTypeSeason  = 3
+	MediaTypeEpisode = 4
+	MediaTypeArtist  = 8
+	MediaTypeAlbum   = 9
+	MediaTypeTrack   = 10
+	MediaTypePhoto   = 13
+)
+
+const (
+	StreamTypeVideo    = 1
+	StreamTypeAudio    = 2
+	StreamTypeSubtitle = 3
+)
+
+// Stream is a video, audio or subtitle track within a Part.
+type Stream struct {
+	Id           int     `xml:"id,attr" json:"id"`
+	StreamType   int     `xml:"streamType,attr" json:"streamType"`
+	Index        int     `xml:"index,attr" json:"index"`
+	Key          string  `xml:"key,attr" json:"key"`
+	Codec        string  `xml:"codec,attr" json:"codec"`
+	Format       string  `xml:"format,attr" json:"format"`
+	Bitrate      int     `xml:"bitrate,attr" json:"bitrate"`
+	Language     string  `xml:"language,attr" json:"language"`
+	LanguageCode string  `xml:"languageCode,attr" json:"languageCode"`
+	Title        string  `xml:"title,attr" json:"title"`
+	DisplayTitle string  `xml:"displayTitle,attr" json:"displayTitle"`
+	Channels     int     `xml:"channels,attr" json:"channels"`
+	Width        int     `xml:"width,attr" json:"width"`
+	Height       int     `xml:"height,attr" json:"height"`
+	FrameRate    float64 `xml:"frameRate,attr" json:"frameRate"`
+	Default      Bool    `xml:"default,attr" json:"default"`
+	Selected     Bool    `xml:"selected,attr" json:"selected"`
+	Forced       Bool    `xml:"forced,attr" json:"forced"`
+
+	// only set on subtitles found by SearchSubtitles
+	HearingImpaired Bool   `xml:"hearingImpaired,attr" json:"hearingImpaired"`
+	ProviderTitle   string `xml:"providerTitle,attr" json:"providerTitle"`
+	SourceKey       string `xml:"sourceKey,attr" json:"sourceKey"`
+	Score           int    `xml:"score,attr" json:"score"`
+}
@@ -0,0 +1,32 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+)
+
+// Trash lists the section's items whose files have gone missing. They
+// stay in the library, with DeletedAt set, until the trash is emptied.
+func (section *Section) Trash(ctx context.Context) (*MediaContainer, error) {
+	return section.All(ctx, NewFilter().Where("trash", OpIs, "1"), 0, 0)
+}
+
+// EmptyTrash removes the section's items whose files have gone missing.
+func (section *Section) EmptyTrash(ctx context.Context) error {
+	return section.server.action(ctx, "PUT", section.path("/emptyTrash"), nil)
+}
+
+// CleanBundles deletes metadata bundles (posters, art and so on) no
+// longer used by any item.
+func (server *PlexServer) CleanBundles(ctx context.Context) error {
+	return server.action(ctx, "PUT", "/library/clean/bundles", nil)
+}
+
+// OptimizeDatabase compacts the server's database in the background. The
+// server can be slow to respond until it is done.
+func (server *PlexServer) OptimizeDatabase(ctx context.Context) error {
+	params := url.Values{}
+	params.Set("async", "1")
+
+	return server.action(ctx, "PUT", "/library/optimize", params)
+}
@@ -0,0 +1,118 @@
+package goplex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Marker types.
+const (
+	MarkerIntro      = "intro"
+	MarkerCredits    = "credits"
+	MarkerCommercial = "commercial"
+)
+
+type Chapter struct {
+	Id              int    `xml:"id,attr" json:"id"`
+	Index           int    `xml:"index,attr" json:"index"`
+	Tag             string `xml:"tag,attr" json:"tag"`
+	Thumb           string `xml:"thumb,attr" json:"thumb"`
+	StartTimeOffset int64  `xml:"startTimeOffset,attr" json:"startTimeOffset"`
+	EndTimeOffset   int64  `xml:"endTimeOffset,attr" json:"endTimeOffset"`
+}
+
+func (chapter *Chapter) Start() time.Duration {
+	return time.Duration(chapter.StartTimeOffset) * time.Millisecond
+}
+
+func (chapter *Chapter) End() time.Duration {
+	return time.Duration(chapter.EndTimeOffset) * time.Millisecond
+}
+
+// Marker is a detected (or hand made) part of a video worth skipping, such
+// as the intro or the credits.
+type Marker struct {
+	Id              int    `xml:"id,attr" json:"id"`
+	Type            string `xml:"type,attr" json:"type"`
+	StartTimeOffset int64  `xml:"startTimeOffset,attr" json:"startTimeOffset"`
+	EndTimeOffset   int64  `xml:"endTimeOffset,attr" json:"endTimeOffset"`
+
+	// Final is set on the credits marker that runs to the end of the video.
+	Final Bool `xml:"final,attr" json:"final"`
+}
+
+func (marker *Marker) Start() time.Duration {
+	return time.Duration(marker.StartTimeOffset) * time.Millisecond
+}
+
+func (marker *Marker) End() time.Duration {
+	return time.Duration(marker.EndTimeOffset) * time.Millisecond
+}
+
+// MarkersOfType returns the video's markers of the given Marker* type, in
+// the order they appear.
+func (video *Video) MarkersOfType(markerType string) []*Marker {
+	var markers []*Marker
+	for _, marker := range video.Markers {
+		if marker.Type == markerType {
+			markers = append(markers, marker)
+		}
+	}
+	return markers
+}
+
+// Intro returns the video's intro marker, or nil if there is none.
+func (video *Video) Intro() *Marker {
+	markers := video.MarkersOfType(MarkerIntro)
+	if len(markers) == 0 {
+		return nil
+	}
+	return markers[0]
+}
+
+type markerContainer struct {
+	Markers []*Marker `xml:"Marker" json:"Marker"`
+}
+
+func markerParams(markerType string, start, end time.Duration) url.Values {
+	params := url.Values{}
+	params.Set("type", markerType)
+	params.Set("startTimeOffset", strconv.FormatInt(start.Milliseconds(), 10))
+	params.Set("endTimeOffset", strconv.FormatInt(end.Milliseconds(), 10))
+	return params
+}
+
+// AddMarker adds an intro or credits marker to a movie or episode, e.g.
+// where the automatic detection missed one.
+func (item *Item) AddMarker(ctx context.Context, markerType string, start, end time.Duration) (*Marker, error) {
+	var container markerContainer
+	err := item.server.fetch(ctx, "POST", item.path("/markers"), markerParams(markerType, start, end), &container)
+	if err != nil {
+		return nil, err
+	}
+	if len(container.Markers) == 0 {
+		return nil, fmt.Errorf("server didn't return the new %s marker", markerType)
+	}
+
+	return container.Markers[0], nil
+}
+
+// UpdateMarker moves an existing marker, updating it in place.
+func (item *Item) UpdateMarker(ctx context.Context, marker *Marker, start, end time.Duration) error {
+	path := item.path("/markers/" + strconv.Itoa(marker.Id))
+	err := item.server.action(ctx, "PUT", path, markerParams(marker.Type, start, end))
+	if err != nil {
+		return err
+	}
+
+	marker.StartTimeOffset = start.Milliseconds()
+	marker.EndTimeOffset = end.Milliseconds()
+	return nil
+}
+
+func (item *Item) DeleteMarker(ctx context.Context, marker *Marker) error {
+	return item.server.action(ctx, "DELETE", item.path("/markers/"+strconv.Itoa(marker.Id)), nil)
+}
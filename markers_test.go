@@ -0,0 +1,46 @@
+package goplex
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMarkerEditing(t *testing.T) {
+	recorder, server := newRecordingServer(t, "application/xml",
+		`<MediaContainer size="1"><Marker id="7" type="intro" startTimeOffset="1000" endTimeOffset="61500"/></MediaContainer>`)
+	item := server.Item("42")
+	ctx := context.Background()
+
+	marker, err := item.AddMarker(ctx, MarkerIntro, time.Second, 61500*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marker.Id != 7 || marker.Type != MarkerIntro {
+		t.Errorf("got marker %+v", marker)
+	}
+	checkMarkerRequest(t, recorder.last(t), "POST", "/library/metadata/42/markers", "endTimeOffset=61500&startTimeOffset=1000&type=intro")
+
+	err = item.UpdateMarker(ctx, marker, 2*time.Second, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marker.StartTimeOffset != 2000 || marker.EndTimeOffset != 60000 {
+		t.Errorf("marker not updated: %+v", marker)
+	}
+	checkMarkerRequest(t, recorder.last(t), "PUT", "/library/metadata/42/markers/7", "endTimeOffset=60000&startTimeOffset=2000&type=intro")
+
+	err = item.DeleteMarker(ctx, marker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMarkerRequest(t, recorder.last(t), "DELETE", "/library/metadata/42/markers/7", "")
+}
+
+func checkMarkerRequest(t *testing.T, request *http.Request, method, path, query string) {
+	t.Helper()
+	if request.Method != method || request.URL.Path != path || request.URL.RawQuery != query {
+		t.Errorf("got %s %s?%s, want %s %s?%s", request.Method, request.URL.Path, request.URL.RawQuery, method, path, query)
+	}
+}
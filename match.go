@@ -0,0 +1,79 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// MatchOptions narrows down Item.Matches. With all fields empty the
+// server searches using what it already knows about the item.
+type MatchOptions struct {
+	Title    string
+	Year     int
+	Agent    string
+	Language string
+}
+
+// SearchResult is a candidate match from the item's agent.
+type SearchResult struct {
+	Guid    string `xml:"guid,attr" json:"guid"`
+	Name    string `xml:"name,attr" json:"name"`
+	Year    int    `xml:"year,attr" json:"year"`
+	Summary string `xml:"summary,attr" json:"summary"`
+	Thumb   string `xml:"thumb,attr" json:"thumb"`
+	Score   int    `xml:"score,attr" json:"score"`
+	Matched Bool   `xml:"matched,attr" json:"matched"`
+}
+
+type searchResultContainer struct {
+	Results []*SearchResult `xml:"SearchResult" json:"SearchResult"`
+}
+
+// Matches asks the item's agent for what the item could be, best match
+// first.
+func (item *Item) Matches(ctx context.Context, options MatchOptions) ([]*SearchResult, error) {
+	params := url.Values{}
+	if len(options.Title) > 0 || options.Year != 0 || len(options.Agent) > 0 || len(options.Language) > 0 {
+		params.Set("manual", "1")
+	}
+	if len(options.Title) > 0 {
+		params.Set("title", options.Title)
+	}
+	if options.Year != 0 {
+		params.Set("year", strconv.Itoa(options.Year))
+	}
+	if len(options.Agent) > 0 {
+		params.Set("agent", options.Agent)
+	}
+	if len(options.Language) > 0 {
+		params.Set("language", options.Language)
+	}
+
+	var container searchResultContainer
+	err := item.server.get(ctx, item.path("/matches"), params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Results, nil
+}
+
+// Match identifies the item as result, one of the results of Matches, and
+// downloads its metadata.
+func (item *Item) Match(ctx context.Context, result *SearchResult) error {
+	params := url.Values{}
+	params.Set("guid", result.Guid)
+	params.Set("name", result.Name)
+	if result.Year != 0 {
+		params.Set("year", strconv.Itoa(result.Year))
+	}
+
+	return item.server.action(ctx, "PUT", item.path("/match"), params)
+}
+
+// Unmatch forgets what the item was identified as, leaving it with only
+// the metadata that can be read from its files.
+func (item *Item) Unmatch(ctx context.Context) error {
+	return item.server.action(ctx, "PUT", item.path("/unmatch"), nil)
+}
@@ -0,0 +1,192 @@
+package goplex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Notification types sent over the notification socket.
+const (
+	NotificationPlaying         = "playing"
+	NotificationTimeline        = "timeline"
+	NotificationActivity        = "activity"
+	NotificationStatus          = "status"
+	NotificationTranscodeUpdate = "transcodeSession.update"
+	NotificationReachability    = "reachability"
+	NotificationUpdateState     = "update.statechange"
+)
+
+// Notification is one event pushed by the server. Only the list matching
+// Type is filled in.
+type Notification struct {
+	Type string `json:"type"`
+	Size int    `json:"size"`
+
+	PlaySessionStates []*PlaySessionState     `json:"PlaySessionStateNotification"`
+	TimelineEntries   []*TimelineEntry        `json:"TimelineEntry"`
+	Activities        []*ActivityNotification `json:"ActivityNotification"`
+	Statuses          []*StatusNotification   `json:"StatusNotification"`
+	TranscodeSessions []*TranscodeSession     `json:"TranscodeSession"`
+}
+
+// PlaySessionState reports a change in a playback session: started,
+// paused, seeked, stopped.
+type PlaySessionState struct {
+	SessionKey       string `json:"sessionKey"`
+	ClientIdentifier string `json:"clientIdentifier"`
+	RatingKey        string `json:"ratingKey"`
+	Key              string `json:"key"`
+	Guid             string `json:"guid"`
+	State            string `json:"state"`
+	ViewOffset       int64  `json:"viewOffset"`
+	PlayQueueItemId  int    `json:"playQueueItemID"`
+}
+
+// TimelineEntry reports an item being added to, updated in or removed from
+// a library.
+type TimelineEntry struct {
+	Identifier    string `json:"identifier"`
+	SectionId     string `json:"sectionID"`
+	ItemId        string `json:"itemID"`
+	Type          int    `json:"type"`
+	Title         string `json:"title"`
+	State         int    `json:"state"`
+	MetadataState string `json:"metadataState"`
+	MediaState    string `json:"mediaState"`
+	UpdatedAt     int64  `json:"updatedAt"`
+}
+
+// ActivityNotification reports progress of a long running server task.
+type ActivityNotification struct {
+	Event    string    `json:"event"`
+	Uuid     string    `json:"uuid"`
+	Activity *Activity `json:"Activity"`
+}
+
+// Activity is a long running task on the server, such as a library scan.
+type Activity struct {
+	Uuid        string           `xml:"uuid,attr" json:"uuid"`
+	Type        string           `xml:"type,attr" json:"type"`
+	Title       string           `xml:"title,attr" json:"title"`
+	Subtitle    string           `xml:"subtitle,attr" json:"subtitle"`
+	Progress    int              `xml:"progress,attr" json:"progress"`
+	UserId      int              `xml:"userID,attr" json:"userID"`
+	Cancellable Bool             `xml:"cancellable,attr" json:"cancellable"`
+	Context     *ActivityContext `xml:"Context" json:"Context"`
+}
+
+type ActivityContext struct {
+	LibrarySectionId string `xml:"librarySectionID,attr" json:"librarySectionID"`
+}
+
+type StatusNotification struct {
+	Title            string `json:"title"`
+	Description      string `json:"description"`
+	NotificationName string `json:"notificationName"`
+}
+
+// how long to wait between reconnection attempts
+const (
+	notificationsMinBackoff = time.Second
+	notificationsMaxBackoff = time.Minute
+)
+
+// Notifications connects to the server's notification socket and delivers
+// events on the returned channel until ctx is done, at which point the
+// channel is closed. A dropped connection is re-established with increasing
+// delays; events sent while disconnected are lost.
+//
+// An error is only returned if the first connection fails. The channel is
+// also closed if the server later rejects the token.
+func (server *PlexServer) Notifications(ctx context.Context) (<-chan *Notification, error) {
+	ws, err := server.dialNotifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make(chan *Notification)
+	go func() {
+		defer close(notifications)
+
+		backoff := notificationsMinBackoff
+		for {
+			connected := time.Now()
+			server.readNotifications(ctx, ws, notifications)
+			if ctx.Err() != nil {
+				return
+			}
+
+			// a connection that lasted a while was healthy; start over
+			if time.Since(connected) > notificationsMaxBackoff {
+				backoff = notificationsMinBackoff
+			}
+
+			for {
+				timer := time.NewTimer(backoff)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+
+				backoff *= 2
+				if backoff > notificationsMaxBackoff {
+					backoff = notificationsMaxBackoff
+				}
+
+				ws, err = server.dialNotifications(ctx)
+				if err == nil {
+					break
+				}
+				if errors.Is(err, ErrUnauthorized) || ctx.Err() != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return notifications, nil
+}
+
+func (server *PlexServer) dialNotifications(ctx context.Context) (*wsConn, error) {
+	request, err := server.newRequest(ctx, "GET", "/:/websockets/notifications", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return dialWebsocket(clientOrDefault(server.client).httpClient, request)
+}
+
+// readNotifications forwards notifications until the connection fails or
+// ctx is done, and closes the connection.
+func (server *PlexServer) readNotifications(ctx context.Context, ws *wsConn, notifications chan<- *Notification) {
+	stop := context.AfterFunc(ctx, func() {
+		ws.Close()
+	})
+	defer stop()
+	defer ws.Close()
+
+	for {
+		message, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var envelope struct {
+			NotificationContainer *Notification
+		}
+		err = json.Unmarshal(message, &envelope)
+		if err != nil || envelope.NotificationContainer == nil {
+			continue
+		}
+
+		select {
+		case notifications <- envelope.NotificationContainer:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
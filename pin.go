@@ -0,0 +1,118 @@
+package goplex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrPinExpired = errors.New("pin expired before it was linked")
+
+// PlexPin is a plex.tv link code. The user enters Code at
+// https://plex.tv/link (or signs in through AuthAppUrl) and plex.tv then
+// hands the PIN an auth token.
+type PlexPin struct {
+	Id               int       `xml:"id,attr"`
+	Code             string    `xml:"code,attr"`
+	ClientIdentifier string    `xml:"clientIdentifier,attr"`
+	ExpiresAt        time.Time `xml:"expiresAt,attr"`
+	AuthToken        string    `xml:"authToken,attr"`
+
+	client *Client
+}
+
+// RequestPin creates a new 4 character PIN for the user to link.
+func RequestPin(ctx context.Context) (*PlexPin, error) {
+	return DefaultClient.RequestPin(ctx)
+}
+
+func (client *Client) RequestPin(ctx context.Context) (*PlexPin, error) {
+	request, err := client.newPlexRequest(
+		ctx,
+		"POST",
+		"https://plex.tv/api/v2/pins",
+		"",
+		strings.NewReader("strong=false"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := client.getResponse(request, http.StatusCreated)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pin := PlexPin{client: client}
+	err = unmarshalResponse(response, &pin)
+	if err != nil {
+		return nil, err
+	}
+	return &pin, nil
+}
+
+// Check refreshes the PIN from plex.tv, filling in AuthToken once the user
+// has linked it.
+func (pin *PlexPin) Check(ctx context.Context) error {
+	client := clientOrDefault(pin.client)
+
+	request, err := client.newPlexRequest(
+		ctx,
+		"GET",
+		fmt.Sprintf("https://plex.tv/api/v2/pins/%d", pin.Id),
+		"",
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.getResponse(request, http.StatusOK)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	return unmarshalResponse(response, pin)
+}
+
+// PollPin checks the PIN every interval until the user links it, the PIN
+// expires (ErrPinExpired) or ctx is done.
+func PollPin(ctx context.Context, pin *PlexPin, interval time.Duration) (*UserAuthQuery, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := pin.Check(ctx)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				// plex.tv forgets PINs once they expire
+				return nil, ErrPinExpired
+			}
+			return nil, err
+		}
+
+		if len(pin.AuthToken) > 0 {
+			return &UserAuthQuery{AuthToken: pin.AuthToken, client: pin.client}, nil
+		}
+
+		if !pin.ExpiresAt.IsZero() && time.Now().After(pin.ExpiresAt) {
+			return nil, ErrPinExpired
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package goplex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Player remote controls a Plex player (an app acting in the player role),
+// either through a media server or by talking to it directly.
+type Player struct {
+	MachineIdentifier string
+
+	uri    string
+	token  string
+	client *Client
+
+	// every command carries an increasing id so players can drop stale
+	// or repeated ones
+	commandId atomic.Int64
+}
+
+// Player controls the player with the given machine identifier (e.g. a
+// SessionPlayer's) by proxying commands through the server. The player
+// must be signed in to an account with access to the server.
+func (server *PlexServer) Player(machineIdentifier string) *Player {
+	return &Player{
+		MachineIdentifier: machineIdentifier,
+		uri:               server.Uri,
+		token:             server.Token,
+		client:            server.client,
+	}
+}
+
+// NewPlayer controls a player by sending commands straight to it, e.g. to
+// the Uri of a player found with DiscoverPlayers.
+func (client *Client) NewPlayer(uri, machineIdentifier, token string) *Player {
+	return &Player{
+		MachineIdentifier: machineIdentifier,
+		uri:               strings.TrimRight(uri, "/"),
+		token:             token,
+		client:            client,
+	}
+}
+
+func (player *Player) command(ctx context.Context, path string, params url.Values) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("commandID", strconv.FormatInt(player.commandId.Add(1), 10))
+
+	client := clientOrDefault(player.client)
+	request, err := client.newPlexRequest(ctx, "GET", player.uri+path+"?"+params.Encode(), player.token, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("X-Plex-Target-Client-Identifier", player.MachineIdentifier)
+
+	response, err := client.getResponse(request, http.StatusOK)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	return err
+}
+
+func (player *Player) playback(ctx context.Context, command string, params url.Values) error {
+	return player.command(ctx, "/player/playback/"+command, params)
+}
+
+// serverParams tells the player which server to fetch media from.
+func serverParams(ctx context.Context, server *PlexServer) (url.Values, error) {
+	if len(server.MachineIdentifier) == 0 {
+		_, err := server.Identity(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	serverUri, err := url.Parse(server.Uri)
+	if err != nil {
+		return nil, err
+	}
+	port := serverUri.Port()
+	if len(port) == 0 {
+		port = "80"
+		if serverUri.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	params := url.Values{}
+	params.Set("machineIdentifier", server.MachineIdentifier)
+	params.Set("protocol", serverUri.Scheme)
+	params.Set("address", serverUri.Hostname())
+	params.Set("port", port)
+	if len(server.Token) > 0 {
+		params.Set("token", server.Token)
+	}
+
+	return params, nil
+}
+
+// PlayMedia starts playing the play queue on the player, at the queue's
+// selected item and the given offset into it.
+func (player *Player) PlayMedia(ctx context.Context, queue *PlayQueue, offset time.Duration) error {
+	if len(queue.SelectedMetadataItemId) == 0 {
+		return errors.New("play queue has no selected item")
+	}
+
+	params, err := serverParams(ctx, queue.server)
+	if err != nil {
+		return err
+	}
+	params.Set("key", "/library/metadata/"+queue.SelectedMetadataItemId)
+	params.Set("offset", strconv.FormatInt(offset.Milliseconds(), 10))
+	params.Set("containerKey", "/playQueues/"+strconv.Itoa(queue.Id)+"?own=1&window=200")
+
+	return player.playback(ctx, "playMedia", params)
+}
+
+func (player *Player) Play(ctx context.Context) error {
+	return player.playback(ctx, "play", nil)
+}
+
+func (player *Player) Pause(ctx context.Context) error {
+	return player.playback(ctx, "pause", nil)
+}
+
+func (player *Player) Stop(ctx context.Context) error {
+	return player.playback(ctx, "stop", nil)
+}
+
+func (player *Player) SkipNext(ctx context.Context) error {
+	return player.playback(ctx, "skipNext", nil)
+}
+
+func (player *Player) SkipPrevious(ctx context.Context) error {
+	return player.playback(ctx, "skipPrevious", nil)
+}
+
+// SeekTo jumps to offset into the current item.
+func (player *Player) SeekTo(ctx context.Context, offset time.Duration) error {
+	params := url.Values{}
+	params.Set("offset", strconv.FormatInt(offset.Milliseconds(), 10))
+
+	return player.playback(ctx, "seekTo", params)
+}
+
+// Repeat modes for PlayerParameters.
+const (
+	RepeatOff = 0
+	RepeatOne = 1
+	RepeatAll = 2
+)
+
+// PlayerParameters are the playback settings SetParameters changes. Nil
+// fields are left alone.
+type PlayerParameters struct {
+	// Volume is 0 to 100.
+	Volume  *int
+	Shuffle *bool
+	Repeat  *int
+}
+
+// SetParameters changes the volume, shuffle or repeat mode of what the
+// player is playing. mediaType is one of "video", "music" or "photo".
+func (player *Player) SetParameters(ctx context.Context, mediaType string, parameters PlayerParameters) error {
+	params := url.Values{}
+	params.Set("type", mediaType)
+	if parameters.Volume != nil {
+		params.Set("volume", strconv.Itoa(*parameters.Volume))
+	}
+	if parameters.Shuffle != nil {
+		params.Set("shuffle", boolParam(*parameters.Shuffle))
+	}
+	if parameters.Repeat != nil {
+		params.Set("repeat", strconv.Itoa(*parameters.Repeat))
+	}
+
+	return player.playback(ctx, "setParameters", params)
+}
+
+func (player *Player) navigate(ctx context.Context, command string) error {
+	return player.command(ctx, "/player/navigation/"+command, nil)
+}
+
+func (player *Player) MoveUp(ctx context.Context) error {
+	return player.navigate(ctx, "moveUp")
+}
+
+func (player *Player) MoveDown(ctx context.Context) error {
+	return player.navigate(ctx, "moveDown")
+}
+
+func (player *Player) MoveLeft(ctx context.Context) error {
+	return player.navigate(ctx, "moveLeft")
+}
+
+func (player *Player) MoveRight(ctx context.Context) error {
+	return player.navigate(ctx, "moveRight")
+}
+
+// Select activates the focused element, like pressing OK on a remote.
+func (player *Player) Select(ctx context.Context) error {
+	return player.navigate(ctx, "select")
+}
+
+func (player *Player) Back(ctx context.Context) error {
+	return player.navigate(ctx, "back")
+}
+
+func (player *Player) Home(ctx context.Context) error {
+	return player.navigate(ctx, "home")
+}
+
+func (player *Player) ContextMenu(ctx context.Context) error {
+	return player.navigate(ctx, "contextMenu")
+}
+
+// MirrorDetails makes the player show the details page of an item on the
+// given server, without starting playback.
+func (player *Player) MirrorDetails(ctx context.Context, server *PlexServer, ratingKey string) error {
+	params, err := serverParams(ctx, server)
+	if err != nil {
+		return err
+	}
+	params.Set("key", "/library/metadata/"+ratingKey)
+
+	return player.command(ctx, "/player/mirror/details", params)
+}
@@ -0,0 +1,228 @@
+package goplex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Playlist types.
+const (
+	PlaylistVideo = "video"
+	PlaylistAudio = "audio"
+	PlaylistPhoto = "photo"
+)
+
+type Playlist struct {
+	RatingKey    string `xml:"ratingKey,attr" json:"ratingKey"`
+	Key          string `xml:"key,attr" json:"key"`
+	Guid         string `xml:"guid,attr" json:"guid"`
+	Title        string `xml:"title,attr" json:"title"`
+	Summary      string `xml:"summary,attr" json:"summary"`
+	PlaylistType string `xml:"playlistType,attr" json:"playlistType"`
+	Smart        Bool   `xml:"smart,attr" json:"smart"`
+	Composite    string `xml:"composite,attr" json:"composite"`
+	Duration     int64  `xml:"duration,attr" json:"duration"`
+	LeafCount    int    `xml:"leafCount,attr" json:"leafCount"`
+	AddedAt      int64  `xml:"addedAt,attr" json:"addedAt"`
+	UpdatedAt    int64  `xml:"updatedAt,attr" json:"updatedAt"`
+
+	server *PlexServer
+}
+
+type playlistContainer struct {
+	Playlists []*Playlist `xml:"Playlist" json:"Metadata"`
+}
+
+type playlistItemContainer struct {
+	// videos, tracks or photos, in playlist order
+	Items []*Video `xml:",any" json:"Metadata"`
+}
+
+func (server *PlexServer) playlists(ctx context.Context, method, path string, params url.Values) ([]*Playlist, error) {
+	var container playlistContainer
+	err := server.fetch(ctx, method, path, params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, playlist := range container.Playlists {
+		playlist.server = server
+	}
+	return container.Playlists, nil
+}
+
+// Playlists lists the playlists of the token's user. playlistType is one
+// of the Playlist* constants, or empty for all of them.
+func (server *PlexServer) Playlists(ctx context.Context, playlistType string) ([]*Playlist, error) {
+	var params url.Values
+	if len(playlistType) > 0 {
+		params = url.Values{}
+		params.Set("playlistType", playlistType)
+	}
+
+	return server.playlists(ctx, "GET", "/playlists", params)
+}
+
+// Playlist fetches a single playlist.
+func (server *PlexServer) Playlist(ctx context.Context, ratingKey string) (*Playlist, error) {
+	playlists, err := server.playlists(ctx, "GET", "/playlists/"+url.PathEscape(ratingKey), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(playlists) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return playlists[0], nil
+}
+
+// itemsUri is the server:// uri for one or more library items.
+func (server *PlexServer) itemsUri(ctx context.Context, ratingKeys []string) (string, error) {
+	escaped := make([]string, len(ratingKeys))
+	for i, ratingKey := range ratingKeys {
+		escaped[i] = url.PathEscape(ratingKey)
+	}
+
+	return server.libraryUri(ctx, "/library/metadata/"+strings.Join(escaped, ","))
+}
+
+// CreatePlaylist creates a playlist of the given type (one of the
+// Playlist* constants) holding the given items. Shows, seasons and albums
+// add all of their episodes or tracks.
+func (server *PlexServer) CreatePlaylist(ctx context.Context, title, playlistType string, ratingKeys ...string) (*Playlist, error) {
+	uri, err := server.itemsUri(ctx, ratingKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.createPlaylist(ctx, title, playlistType, uri, false)
+}
+
+func (server *PlexServer) createPlaylist(ctx context.Context, title, playlistType, uri string, smart bool) (*Playlist, error) {
+	params := url.Values{}
+	params.Set("title", title)
+	params.Set("type", playlistType)
+	params.Set("smart", boolParam(smart))
+	params.Set("uri", uri)
+
+	playlists, err := server.playlists(ctx, "POST", "/playlists", params)
+	if err != nil {
+		return nil, err
+	}
+	if len(playlists) == 0 {
+		return nil, fmt.Errorf("server didn't return the new playlist %q", title)
+	}
+
+	return playlists[0], nil
+}
+
+func (playlist *Playlist) path(suffix string) string {
+	return "/playlists/" + url.PathEscape(playlist.RatingKey) + suffix
+}
+
+// Items lists the playlist's videos, tracks or photos. Each has its own
+// PlaylistItemId, which is what MoveItem and RemoveItem take.
+func (playlist *Playlist) Items(ctx context.Context) ([]*Video, error) {
+	var container playlistItemContainer
+	err := playlist.server.get(ctx, playlist.path("/items"), nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Items, nil
+}
+
+// AddItems adds items to the end of the playlist.
+func (playlist *Playlist) AddItems(ctx context.Context, ratingKeys ...string) error {
+	uri, err := playlist.server.itemsUri(ctx, ratingKeys)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("uri", uri)
+
+	return playlist.server.action(ctx, "PUT", playlist.path("/items"), params)
+}
+
+// MoveItem moves the item with the given playlist item id after another
+// one, or to the front if afterItemId is 0.
+func (playlist *Playlist) MoveItem(ctx context.Context, itemId, afterItemId int) error {
+	var params url.Values
+	if afterItemId != 0 {
+		params = url.Values{}
+		params.Set("after", strconv.Itoa(afterItemId))
+	}
+
+	return playlist.server.action(ctx, "PUT", playlist.path(fmt.Sprintf("/items/%d/move", itemId)), params)
+}
+
+func (playlist *Playlist) RemoveItem(ctx context.Context, itemId int) error {
+	return playlist.server.action(ctx, "DELETE", playlist.path(fmt.Sprintf("/items/%d", itemId)), nil)
+}
+
+// Update renames the playlist and replaces its summary.
+func (playlist *Playlist) Update(ctx context.Context, title, summary string) error {
+	params := url.Values{}
+	params.Set("title", title)
+	params.Set("summary", summary)
+
+	err := playlist.server.action(ctx, "PUT", playlist.path(""), params)
+	if err != nil {
+		return err
+	}
+
+	playlist.Title = title
+	playlist.Summary = summary
+	return nil
+}
+
+func (playlist *Playlist) Delete(ctx context.Context) error {
+	return playlist.server.action(ctx, "DELETE", playlist.path(""), nil)
+}
+
+// CopyTo creates a copy of the playlist for another user. server must be
+// the same media server, connected with that user's token, e.g. the
+// AuthToken SwitchHomeUser returns for a home user.
+func (playlist *Playlist) CopyTo(ctx context.Context, server *PlexServer) (*Playlist, error) {
+	items, err := playlist.Items(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("playlist %q is empty", playlist.Title)
+	}
+
+	ratingKeys := make([]string, len(items))
+	for i, item := range items {
+		ratingKeys[i] = item.RatingKey
+	}
+
+	return server.CreatePlaylist(ctx, playlist.Title, playlist.PlaylistType, ratingKeys...)
+}
+
+// CreateSmartPlaylist creates a playlist that keeps itself up to date
+// with the section's items matching filter, or with all of them when filter
+// is nil. The filter should set a Type, e.g. MediaTypeMovie for a
+// PlaylistVideo playlist:
+//
+//	filter := NewFilter().Type(MediaTypeMovie).Unwatched().
+//		Resolution("4k").AddedWithin(30)
+func (section *Section) CreateSmartPlaylist(ctx context.Context, title, playlistType string, filter *Filter) (*Playlist, error) {
+	path := section.path("/all")
+	if filter != nil {
+		if query := filter.encode(); len(query) > 0 {
+			path += "?" + query
+		}
+	}
+
+	uri, err := section.server.libraryUri(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return section.server.createPlaylist(ctx, title, playlistType, uri, true)
+}
@@ -0,0 +1,179 @@
+package goplex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Play queue types, which decide what kind of player picks the queue up.
+const (
+	PlayQueueVideo = "video"
+	PlayQueueAudio = "audio"
+	PlayQueuePhoto = "photo"
+)
+
+// PlayQueue is the list of items a player works through. Every call that
+// changes the queue updates it in place with the server's new version.
+type PlayQueue struct {
+	Id                     int    `xml:"playQueueID,attr" json:"playQueueID"`
+	SelectedItemId         int    `xml:"playQueueSelectedItemID,attr" json:"playQueueSelectedItemID"`
+	SelectedItemOffset     int    `xml:"playQueueSelectedItemOffset,attr" json:"playQueueSelectedItemOffset"`
+	SelectedMetadataItemId string `xml:"playQueueSelectedMetadataItemID,attr" json:"playQueueSelectedMetadataItemID"`
+	IsShuffled             Bool   `xml:"playQueueShuffled,attr" json:"playQueueShuffled"`
+	SourceUri              string `xml:"playQueueSourceURI,attr" json:"playQueueSourceURI"`
+	TotalCount             int    `xml:"playQueueTotalCount,attr" json:"playQueueTotalCount"`
+	Version                int    `xml:"playQueueVersion,attr" json:"playQueueVersion"`
+
+	// Items are videos, tracks or photos. Each has its own PlayQueueItemId,
+	// which is what MoveItem and RemoveItem take.
+	Items []*Video `xml:",any" json:"Metadata"`
+
+	server *PlexServer
+}
+
+type PlayQueueOptions struct {
+	// Type is one of the PlayQueue* constants, PlayQueueVideo by default.
+	Type       string
+	Shuffle    bool
+	Repeat     bool
+	Continuous bool
+
+	// StartRatingKey picks the item to start at when the queue is built
+	// from a container such as a season or playlist.
+	StartRatingKey string
+}
+
+func (options *PlayQueueOptions) params() url.Values {
+	params := url.Values{}
+
+	queueType := options.Type
+	if len(queueType) == 0 {
+		queueType = PlayQueueVideo
+	}
+	params.Set("type", queueType)
+	params.Set("shuffle", boolParam(options.Shuffle))
+	params.Set("repeat", boolParam(options.Repeat))
+	params.Set("continuous", boolParam(options.Continuous))
+	if len(options.StartRatingKey) > 0 {
+		params.Set("key", "/library/metadata/"+options.StartRatingKey)
+	}
+
+	return params
+}
+
+func boolParam(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// CreatePlayQueue creates a play queue from a server:// uri. Most callers
+// want CreatePlayQueueFromItem or CreatePlayQueueFromPlaylist instead.
+func (server *PlexServer) CreatePlayQueue(ctx context.Context, uri string, options PlayQueueOptions) (*PlayQueue, error) {
+	params := options.params()
+	params.Set("uri", uri)
+
+	return server.sendPlayQueue(ctx, "POST", "/playQueues", params)
+}
+
+// CreatePlayQueueFromItem queues an item, or everything in it if it's a
+// show, season, album and so on.
+func (server *PlexServer) CreatePlayQueueFromItem(ctx context.Context, ratingKey string, options PlayQueueOptions) (*PlayQueue, error) {
+	uri, err := server.libraryUri(ctx, "/library/metadata/"+url.PathEscape(ratingKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return server.CreatePlayQueue(ctx, uri, options)
+}
+
+// CreatePlayQueueFromPlaylist queues the items of a playlist.
+func (server *PlexServer) CreatePlayQueueFromPlaylist(ctx context.Context, playlistRatingKey string, options PlayQueueOptions) (*PlayQueue, error) {
+	params := options.params()
+	params.Set("playlistID", playlistRatingKey)
+
+	return server.sendPlayQueue(ctx, "POST", "/playQueues", params)
+}
+
+// PlayQueue fetches an existing play queue.
+func (server *PlexServer) PlayQueue(ctx context.Context, playQueueId int) (*PlayQueue, error) {
+	return server.sendPlayQueue(ctx, "GET", fmt.Sprintf("/playQueues/%d", playQueueId), nil)
+}
+
+func (server *PlexServer) sendPlayQueue(ctx context.Context, method, path string, params url.Values) (*PlayQueue, error) {
+	queue := PlayQueue{server: server}
+	err := server.fetch(ctx, method, path, params, &queue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &queue, nil
+}
+
+func (queue *PlayQueue) path(suffix string) string {
+	return "/playQueues/" + strconv.Itoa(queue.Id) + suffix
+}
+
+// update sends a change to the server and replaces the queue with the
+// version it answers with.
+func (queue *PlayQueue) update(ctx context.Context, method, path string, params url.Values) error {
+	updated, err := queue.server.sendPlayQueue(ctx, method, path, params)
+	if err != nil {
+		return err
+	}
+
+	*queue = *updated
+	return nil
+}
+
+// Refresh fetches the current state of the queue.
+func (queue *PlayQueue) Refresh(ctx context.Context) error {
+	return queue.update(ctx, "GET", queue.path(""), nil)
+}
+
+func (queue *PlayQueue) Shuffle(ctx context.Context) error {
+	return queue.update(ctx, "PUT", queue.path("/shuffle"), nil)
+}
+
+func (queue *PlayQueue) Unshuffle(ctx context.Context) error {
+	return queue.update(ctx, "PUT", queue.path("/unshuffle"), nil)
+}
+
+// AddItem adds an item (or a container's items) to the end of the queue,
+// or right after the current item if next is set.
+func (queue *PlayQueue) AddItem(ctx context.Context, ratingKey string, next bool) error {
+	uri, err := queue.server.libraryUri(ctx, "/library/metadata/"+url.PathEscape(ratingKey))
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("uri", uri)
+	params.Set("next", boolParam(next))
+
+	return queue.update(ctx, "PUT", queue.path(""), params)
+}
+
+// MoveItem moves the item with the given play queue item id after another
+// one, or to the front if afterItemId is 0.
+func (queue *PlayQueue) MoveItem(ctx context.Context, itemId, afterItemId int) error {
+	var params url.Values
+	if afterItemId != 0 {
+		params = url.Values{}
+		params.Set("after", strconv.Itoa(afterItemId))
+	}
+
+	return queue.update(ctx, "PUT", queue.path(fmt.Sprintf("/items/%d/move", itemId)), params)
+}
+
+func (queue *PlayQueue) RemoveItem(ctx context.Context, itemId int) error {
+	return queue.update(ctx, "DELETE", queue.path(fmt.Sprintf("/items/%d", itemId)), nil)
+}
+
+// Clear removes every item from the queue.
+func (queue *PlayQueue) Clear(ctx context.Context) error {
+	return queue.update(ctx, "DELETE", queue.path("/items"), nil)
+}
@@ -1,11 +1,15 @@
 package goplex
 
 import (
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 	"sync"
 )
@@ -14,16 +18,9 @@ type PlexUser struct {
 	AuthToken string
 }
 
-type InvalidHttpStatusCode struct {
-	HttpStatus int
-}
-
-func (e *InvalidHttpStatusCode) Error() string {
-	return fmt.Sprintf("Invalid plex credentials: HTTP=%d", e.HttpStatus)
-}
-
-func newPlexRequest(method, url, authToken string, body io.Reader) (*http.Request, error) {
-	request, err := http.NewRequest(
+func (client *Client) newPlexRequest(ctx context.Context, method, url, authToken string, body io.Reader) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(
+		ctx,
 		method,
 		url,
 		body,
@@ -32,12 +29,8 @@ func newPlexRequest(method, url, authToken string, body io.Reader) (*http.Reques
 		return nil, err
 	}
 
-	request.Header.Set("X-Plex-Platform", "golang")
-	request.Header.Set("X-Plex-Platform-Version", "0.0")
-	request.Header.Set("X-Plex-Provides", "player,controller")
-	request.Header.Set("X-Plex-Version", "0.0")
-	request.Header.Set("X-Plex-Device", "platform")
-	request.Header.Set("X-Plex-Client-Identifier", "identifier")
+	client.headers.apply(request.Header)
+	request.Header.Set("Accept", FormatXml.mimeType())
 
 	if len(authToken) > 0 {
 		request.Header.Add("X-Plex-Token", authToken)
@@ -46,9 +39,40 @@ func newPlexRequest(method, url, authToken string, body io.Reader) (*http.Reques
 	return request, nil
 }
 
-func getResponse(request *http.Request, statusCodes ...int) (*http.Response, error) {
-	client := &http.Client{}
-	response, err := client.Do(request)
+func (client *Client) getResponse(request *http.Request, statusCodes ...int) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := client.do(request, statusCodes...)
+		if err == nil {
+			return response, nil
+		}
+
+		delay, ok := client.retryPolicy.retryDelay(request, attempt, err)
+		if !ok {
+			return nil, err
+		}
+
+		if request.Body != nil {
+			if request.GetBody == nil {
+				return nil, err
+			}
+			request.Body, err = request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (client *Client) do(request *http.Request, statusCodes ...int) (*http.Response, error) {
+	response, err := client.httpClient.Do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -59,51 +83,125 @@ func getResponse(request *http.Request, statusCodes ...int) (*http.Response, err
 		}
 	}
 
-	return nil, &InvalidHttpStatusCode{
-		HttpStatus: response.StatusCode,
-	}
+	defer response.Body.Close()
+	return nil, newHttpStatusError(response)
 }
 
 func unmarshalResponse(response *http.Response, v interface{}) error {
+	if !isJsonResponse(response) {
+		return xml.NewDecoder(response.Body).Decode(v)
+	}
+
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
 		return err
 	}
 
-	return xml.Unmarshal(body, &v)
+	return unmarshalJson(body, v)
 }
 
 type UserAuthQuery struct {
 	AuthToken 	string 	`xml:"authenticationToken,attr"`
 	Email		string	`xml:"email,attr"`
 	UserId		int		`xml:"id,attr"`
+
+	client		*Client
+}
+
+func clientOrDefault(client *Client) *Client {
+	if client == nil {
+		return DefaultClient
+	}
+	return client
+}
+
+var ErrVerificationCodeRequired = errors.New("two-factor verification code required")
+
+// plex.tv error code returned when an account has two-factor auth enabled
+// and the verification code is missing or wrong
+const verificationCodeErrorCode = 1029
+
+type plexTvErrors struct {
+	Errors		[]struct {
+		Code	int		`xml:"code,attr"`
+		Message	string	`xml:"message,attr"`
+	}	`xml:"error"`
+}
+
+type plexTvUser struct {
+	AuthToken	string	`xml:"authToken,attr"`
+	Email		string	`xml:"email,attr"`
+	UserId		int		`xml:"id,attr"`
+}
+
+func SignIn(ctx context.Context, username, password string) (*UserAuthQuery, error) {
+	return DefaultClient.SignIn(ctx, username, password)
+}
+
+func SignInWithCode(ctx context.Context, username, password, verificationCode string) (*UserAuthQuery, error) {
+	return DefaultClient.SignInWithCode(ctx, username, password, verificationCode)
 }
 
-func SignIn(username, password string) (*UserAuthQuery, error) {
-	request, err := newPlexRequest(
+func (client *Client) SignIn(ctx context.Context, username, password string) (*UserAuthQuery, error) {
+	return client.SignInWithCode(ctx, username, password, "")
+}
+
+// SignInWithCode signs in an account that has two-factor authentication
+// enabled, passing the current code from the user's authenticator app.
+// Without a (valid) code such accounts fail with ErrVerificationCodeRequired.
+func (client *Client) SignInWithCode(ctx context.Context, username, password, verificationCode string) (*UserAuthQuery, error) {
+	form := url.Values{}
+	form.Set("login", username)
+	form.Set("password", password)
+	form.Set("rememberMe", "true")
+	if len(verificationCode) > 0 {
+		form.Set("verificationCode", verificationCode)
+	}
+
+	request, err := client.newPlexRequest(
+		ctx,
 		"POST",
-		"https://my.plexapp.com/users/sign_in.xml",
+		"https://plex.tv/api/v2/users/signin",
 		"",
-		nil,
+		strings.NewReader(form.Encode()),
 	)
 	if err != nil {
 		return nil, err
 	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	request.SetBasicAuth(username, password)
-
-	response, err := getResponse(request, http.StatusCreated)
+	response, err := client.getResponse(request, http.StatusCreated, http.StatusUnauthorized)
 	if response != nil {defer response.Body.Close()}
 	if err != nil {
 		return nil, err
 	}
 
-	var q UserAuthQuery
+	if response.StatusCode == http.StatusUnauthorized {
+		statusErr := newHttpStatusError(response)
+
+		var q plexTvErrors
+		err = xml.Unmarshal([]byte(statusErr.Body), &q)
+		if err == nil {
+			for _, e := range q.Errors {
+				if e.Code == verificationCodeErrorCode {
+					return nil, ErrVerificationCodeRequired
+				}
+			}
+		}
+		return nil, statusErr
+	}
+
+	var q plexTvUser
 	err = unmarshalResponse(response, &q)
 	if err != nil {
 		return nil, err
 	}
-	return &q, nil
+	return &UserAuthQuery{
+		AuthToken: q.AuthToken,
+		Email: q.Email,
+		UserId: q.UserId,
+		client: client,
+	}, nil
 }
 
 type PlexDeviceConnection struct {
@@ -112,14 +210,30 @@ type PlexDeviceConnection struct {
 	Port					string 	`xml:"port,attr"`
 	Uri						string	`xml:"uri,attr"`
 	IsLocal					bool	`xml:"local,attr"`
+	IsRelay					bool	`xml:"relay,attr"`
+}
+
+// Equal reports whether both connections point at the same Uri. Other
+// fields such as IsLocal are not compared.
+func (connection *PlexDeviceConnection) Equal(other *PlexDeviceConnection) bool {
+	if connection == nil || other == nil {
+		return connection == other
+	}
+
+	return connection.Uri == other.Uri
 }
 
-func (connection *PlexDeviceConnection) Validate (client *http.Client) bool {
+func (connection *PlexDeviceConnection) Validate (ctx context.Context, client *http.Client) bool {
 	if client == nil {
 		client = http.DefaultClient
 	}
 
-	response, err := client.Get(connection.Uri)
+	request, err := http.NewRequestWithContext(ctx, "GET", connection.Uri, nil)
+	if err != nil {
+		return false
+	}
+
+	response, err := client.Do(request)
 	if response != nil {
 		defer response.Body.Close()
 	}
@@ -145,15 +259,93 @@ type PlexDevice struct {
 	HasPublicAddressMatches	bool	`xml:"publicAddressMatches,attr"`
 	IsOnline				bool	`xml:"presence,attr"`
 	SourceTitle				string	`xml:"sourceTitle,attr"`
+	AccessToken				string	`xml:"accessToken,attr"`
 
 	Connections				[]*PlexDeviceConnection	`xml:"Connection"`
+
+	client					*Client
+}
+
+// Equal reports whether both devices have the same ClientIdentifier, i.e.
+// are the same server or player. Names, presence and connections may
+// differ between two refreshes of the same device.
+func (device *PlexDevice) Equal(other *PlexDevice) bool {
+	if device == nil || other == nil {
+		return device == other
+	}
+
+	return device.ClientIdentifier == other.ClientIdentifier
 }
 
 type NoValidConnection struct {}
 func (*NoValidConnection) Error() string { return "No valid connection found." }
 
-func (device *PlexDevice) GetBestConnection(connectTimeout time.Duration) (*PlexDeviceConnection, error) {
-	cxns := make(chan *PlexDeviceConnection)
+type ConnectionOptions struct {
+	// PreferHttps picks an https connection over an http one when the
+	// device advertises both for the same address and port, e.g. so that
+	// browser-embedded clients don't trip over mixed content.
+	PreferHttps		bool
+
+	// ScaleTimeouts gives each connection its own probe timeout depending
+	// on how far away it is: a local connection that doesn't answer quickly
+	// is dead, while a relay deserves a couple of seconds. Zero values fall
+	// back to the Default*Timeout constants. The overall timeout or
+	// deadline of the context passed to GetBestConnection still applies.
+	ScaleTimeouts	bool
+	LocalTimeout	time.Duration
+	RemoteTimeout	time.Duration
+	RelayTimeout	time.Duration
+}
+
+const (
+	DefaultLocalTimeout		= 300 * time.Millisecond
+	DefaultRemoteTimeout	= 1 * time.Second
+	DefaultRelayTimeout		= 2 * time.Second
+)
+
+// probeClient returns the http client used to validate the connection.
+func (options *ConnectionOptions) probeClient(client *http.Client, connection *PlexDeviceConnection) *http.Client {
+	if !options.ScaleTimeouts {
+		return client
+	}
+
+	var timeout time.Duration
+	switch {
+	case connection.IsRelay:
+		timeout = options.RelayTimeout
+		if timeout == 0 {
+			timeout = DefaultRelayTimeout
+		}
+	case connection.IsLocal:
+		timeout = options.LocalTimeout
+		if timeout == 0 {
+			timeout = DefaultLocalTimeout
+		}
+	default:
+		timeout = options.RemoteTimeout
+		if timeout == 0 {
+			timeout = DefaultRemoteTimeout
+		}
+	}
+
+	probe := *client
+	probe.Timeout = timeout
+	return &probe
+}
+
+// GetBestConnection probes all of the device's connections at once and
+// returns the first one to answer. Use a context with a timeout or deadline
+// to bound how long to wait; remaining probes are cancelled on return.
+func (device *PlexDevice) GetBestConnection(ctx context.Context) (*PlexDeviceConnection, error) {
+	return device.GetBestConnectionWithOptions(ctx, ConnectionOptions{})
+}
+
+func (device *PlexDevice) GetBestConnectionWithOptions(ctx context.Context, options ConnectionOptions) (*PlexDeviceConnection, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	httpClient := clientOrDefault(device.client).httpClient
+	cxns := make(chan *PlexDeviceConnection, len(device.Connections))
 
 	var connectionAttempts sync.WaitGroup
 
@@ -162,7 +354,7 @@ func (device *PlexDevice) GetBestConnection(connectTimeout time.Duration) (*Plex
 		go func (cxn *PlexDeviceConnection) {
 			defer connectionAttempts.Done()
 
-			result := cxn.Validate(nil)
+			result := cxn.Validate(ctx, options.probeClient(httpClient, cxn))
 			if result {
 				cxns <- cxn
 			}
@@ -174,25 +366,92 @@ func (device *PlexDevice) GetBestConnection(connectTimeout time.Duration) (*Plex
 		close(cxns)
 	}(&connectionAttempts)
 
-	timeout := time.After(connectTimeout)
+	// an http connection that has an https sibling is held back until the
+	// sibling answers, the probes run out, or we time out
+	var fallback *PlexDeviceConnection
+
 	for {
 		select {
-		case cxn := <-cxns:
+		case cxn, ok := <-cxns:
+			if !ok {
+				if fallback != nil {
+					return fallback, nil
+				}
+				return nil, &NoValidConnection{}
+			}
+			if options.PreferHttps && fallback == nil && device.httpsSibling(cxn) != nil {
+				fallback = cxn
+				continue
+			}
+			if fallback != nil && !cxn.Equal(device.httpsSibling(fallback)) {
+				continue
+			}
 			return cxn, nil
-		case <- timeout:
+		case <-ctx.Done():
+			if fallback != nil {
+				return fallback, nil
+			}
 			return nil, &NoValidConnection{}
 		}
 	}
 }
 
+// httpsSibling returns the https connection advertised for the same
+// address and port as the given http connection, if any.
+func (device *PlexDevice) httpsSibling(connection *PlexDeviceConnection) *PlexDeviceConnection {
+	if connection.Protocol != "http" {
+		return nil
+	}
+
+	for _, c := range device.Connections {
+		if c.Protocol == "https" && c.Address == connection.Address && c.Port == connection.Port {
+			return c
+		}
+	}
+
+	return nil
+}
+
 type PlexResourceContainer struct {
 	Devices		[]*PlexDevice	`xml:"Device"`
 }
 
-func (user *UserAuthQuery) Devices() ([]*PlexDevice, error) {
-	request, err := newPlexRequest(
+type DevicesOptions struct {
+	IncludeHttps	bool
+	IncludeRelay	bool
+	IncludeIPv6		bool
+}
+
+var DefaultDevicesOptions = DevicesOptions{IncludeHttps: true}
+
+func (options *DevicesOptions) query() string {
+	params := url.Values{}
+	if options.IncludeHttps {
+		params.Set("includeHttps", "1")
+	}
+	if options.IncludeRelay {
+		params.Set("includeRelay", "1")
+	}
+	if options.IncludeIPv6 {
+		params.Set("includeIPv6", "1")
+	}
+
+	return params.Encode()
+}
+
+func (user *UserAuthQuery) Devices(ctx context.Context) ([]*PlexDevice, error) {
+	return user.DevicesWithOptions(ctx, DefaultDevicesOptions)
+}
+
+// DevicesWithOptions lists the account's devices, letting the caller choose
+// which kinds of connections plex.tv returns for each.
+func (user *UserAuthQuery) DevicesWithOptions(ctx context.Context, options DevicesOptions) ([]*PlexDevice, error) {
+	client := clientOrDefault(user.client)
+
+	request, err := client.newPlexRequest(
+		ctx,
 		"GET",
-		"https://plex.tv/api/resources?includeHttps=1",
+		"https://plex.tv/api/resources?" + options.query(),
 		user.AuthToken,
 		nil,
 	)
@@ -200,7 +459,8 @@ func (user *UserAuthQuery) Devices() ([]*PlexDevice, error) {
 		return nil, err
 	}
 
-	response, err := getResponse(request, http.StatusOK)
+	response, err := client.getResponse(request, http.StatusOK)
+	if response != nil {defer response.Body.Close()}
 	if err != nil {
 		return nil, err
 	}
@@ -211,5 +471,136 @@ func (user *UserAuthQuery) Devices() ([]*PlexDevice, error) {
 		return nil, err
 	}
 
+	for _, device := range q.Devices {
+		device.client = client
+	}
+
 	return q.Devices, nil
 }
+
+type GeoLocation struct {
+	PublicAddress				string	`xml:"-"`
+	Code						string	`xml:"code,attr"`
+	ContinentCode				string	`xml:"continent_code,attr"`
+	Country						string	`xml:"country,attr"`
+	City						string	`xml:"city,attr"`
+	Subdivisions				string	`xml:"subdivisions,attr"`
+	PostalCode					string	`xml:"postal_code,attr"`
+	TimeZone					string	`xml:"time_zone,attr"`
+	Coordinates					string	`xml:"coordinates,attr"`
+	IsEuropeanUnionMember		bool	`xml:"european_union_member,attr"`
+	InPrivacyRestrictedCountry	bool	`xml:"in_privacy_restricted_country,attr"`
+}
+
+// Location asks plex.tv for the public address this client is connecting
+// from and where that address is located.
+func (user *UserAuthQuery) Location(ctx context.Context) (*GeoLocation, error) {
+	client := clientOrDefault(user.client)
+
+	request, err := client.newPlexRequest(
+		ctx,
+		"GET",
+		"https://plex.tv/:/ip",
+		user.AuthToken,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.getResponse(request, http.StatusOK)
+	if response != nil {defer response.Body.Close()}
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	address := strings.TrimSpace(string(body))
+
+	request, err = client.newPlexRequest(
+		ctx,
+		"GET",
+		"https://plex.tv/api/v2/geoip?ip_address=" + url.QueryEscape(address),
+		user.AuthToken,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err = client.getResponse(request, http.StatusOK)
+	if response != nil {defer response.Body.Close()}
+	if err != nil {
+		return nil, err
+	}
+
+	var q GeoLocation
+	err = unmarshalResponse(response, &q)
+	if err != nil {
+		return nil, err
+	}
+	q.PublicAddress = address
+
+	return &q, nil
+}
+
+var ErrDeviceNotFound = errors.New("device not found")
+
+// Device fetches the account's devices and returns the one with the given
+// client identifier, or an error wrapping ErrDeviceNotFound.
+func (user *UserAuthQuery) Device(ctx context.Context, clientIdentifier string) (*PlexDevice, error) {
+	devices, err := user.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, device := range devices {
+		if device.ClientIdentifier == clientIdentifier {
+			return device, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrDeviceNotFound, clientIdentifier)
+}
+
+// Presence returns whether plex.tv currently sees each device online, keyed
+// by client identifier. This is what plex.tv last heard from the device,
+// not a probe of its connections; use GetBestConnection to check that a
+// device is actually reachable from here.
+func (user *UserAuthQuery) Presence(ctx context.Context) (map[string]bool, error) {
+	devices, err := user.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	presence := make(map[string]bool, len(devices))
+	for _, device := range devices {
+		presence[device.ClientIdentifier] = device.IsOnline
+	}
+
+	return presence, nil
+}
+
+// AuthAppUrl builds the app.plex.tv address a web app redirects the user to
+// in order to sign in and link the PIN with the given code. clientIdentifier
+// must be the X-Plex-Client-Identifier the PIN was created with.
+//
+// Once the user has signed in, app.plex.tv sends the browser to forwardUrl
+// (if not empty). The app then checks the PIN again to pick up the issued
+// auth token; nothing is passed back on the forwardUrl itself.
+func AuthAppUrl(clientIdentifier, code, product, forwardUrl string) string {
+	params := url.Values{}
+	params.Set("clientID", clientIdentifier)
+	params.Set("code", code)
+	if len(product) > 0 {
+		params.Set("context[device][product]", product)
+	}
+	if len(forwardUrl) > 0 {
+		params.Set("forwardUrl", forwardUrl)
+	}
+
+	return "https://app.plex.tv/auth#?" + params.Encode()
+}
@@ -0,0 +1,158 @@
+package goplex
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPlexDeviceConnectionEqual(t *testing.T) {
+	local := &PlexDeviceConnection{Protocol: "http", Address: "10.0.0.2", Port: "32400", Uri: "http://10.0.0.2:32400", IsLocal: true}
+	sameUri := &PlexDeviceConnection{Protocol: "http", Address: "10.0.0.2", Port: "32400", Uri: "http://10.0.0.2:32400", IsLocal: false, IsRelay: true}
+	otherUri := &PlexDeviceConnection{Protocol: "http", Address: "10.0.0.2", Port: "32400", Uri: "http://10.0.0.3:32400", IsLocal: true}
+	var none *PlexDeviceConnection
+
+	tests := []struct {
+		name  string
+		a, b  *PlexDeviceConnection
+		equal bool
+	}{
+		{"same pointer", local, local, true},
+		{"same uri, other fields differ", local, sameUri, true},
+		{"other uri, other fields the same", local, otherUri, false},
+		{"nil argument", local, nil, false},
+		{"nil receiver", none, local, false},
+		{"both nil", none, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if equal := test.a.Equal(test.b); equal != test.equal {
+				t.Errorf("Equal() = %v, want %v", equal, test.equal)
+			}
+		})
+	}
+}
+
+func TestPlexDeviceEqual(t *testing.T) {
+	device := &PlexDevice{
+		Name:             "Living room",
+		ClientIdentifier: "abc",
+		IsOnline:         true,
+		Connections:      []*PlexDeviceConnection{{Uri: "http://10.0.0.2:32400"}},
+	}
+	refreshed := &PlexDevice{
+		Name:             "Lounge",
+		ClientIdentifier: "abc",
+		IsOnline:         false,
+		Connections:      []*PlexDeviceConnection{{Uri: "https://10-0-0-2.abc.plex.direct:32400"}, {Uri: "http://10.0.0.2:32400"}},
+	}
+	other := &PlexDevice{
+		Name:             "Living room",
+		ClientIdentifier: "def",
+		IsOnline:         true,
+		Connections:      device.Connections,
+	}
+	var none *PlexDevice
+
+	tests := []struct {
+		name  string
+		a, b  *PlexDevice
+		equal bool
+	}{
+		{"same pointer", device, device, true},
+		{"same identifier, connections differ", device, refreshed, true},
+		{"other identifier, connections the same", device, other, false},
+		{"nil argument", device, nil, false},
+		{"nil receiver", none, device, false},
+		{"both nil", none, nil, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if equal := test.a.Equal(test.b); equal != test.equal {
+				t.Errorf("Equal() = %v, want %v", equal, test.equal)
+			}
+		})
+	}
+}
+
+// siblingDevice advertises an http and an https connection for the same
+// address and port, served by the given test servers.
+func siblingDevice(httpServer, httpsServer *httptest.Server) *PlexDevice {
+	return &PlexDevice{
+		ClientIdentifier: "abc",
+		client:           NewClient(WithHttpClient(httpsServer.Client())),
+		Connections: []*PlexDeviceConnection{
+			{Protocol: "http", Address: "10.0.0.2", Port: "32400", Uri: httpServer.URL, IsLocal: true},
+			{Protocol: "https", Address: "10.0.0.2", Port: "32400", Uri: httpsServer.URL, IsLocal: true},
+		},
+	}
+}
+
+func TestGetBestConnectionPrefersHttpsSibling(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer httpServer.Close()
+	// the https connection answers last, so it only wins if the http one
+	// is held back for it
+	httpsServer := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer httpsServer.Close()
+
+	device := siblingDevice(httpServer, httpsServer)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	connection, err := device.GetBestConnectionWithOptions(ctx, ConnectionOptions{PreferHttps: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if connection != device.Connections[1] {
+		t.Errorf("got %s, want the https connection %s", connection.Uri, device.Connections[1].Uri)
+	}
+}
+
+func TestGetBestConnectionFallsBackToHttp(t *testing.T) {
+	httpServer := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer httpServer.Close()
+	// closed before probing, so the https probe fails
+	httpsServer := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	httpsServer.Close()
+
+	device := siblingDevice(httpServer, httpsServer)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	connection, err := device.GetBestConnectionWithOptions(ctx, ConnectionOptions{PreferHttps: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if connection != device.Connections[0] {
+		t.Errorf("got %s, want the http connection %s", connection.Uri, device.Connections[0].Uri)
+	}
+}
+
+func TestDevicesOptionsQuery(t *testing.T) {
+	tests := []struct {
+		options DevicesOptions
+		query   string
+	}{
+		{DevicesOptions{}, ""},
+		{DevicesOptions{IncludeHttps: true}, "includeHttps=1"},
+		{DevicesOptions{IncludeRelay: true}, "includeRelay=1"},
+		{DevicesOptions{IncludeIPv6: true}, "includeIPv6=1"},
+		{DevicesOptions{IncludeHttps: true, IncludeRelay: true}, "includeHttps=1&includeRelay=1"},
+		{DevicesOptions{IncludeHttps: true, IncludeIPv6: true}, "includeHttps=1&includeIPv6=1"},
+		{DevicesOptions{IncludeRelay: true, IncludeIPv6: true}, "includeIPv6=1&includeRelay=1"},
+		{DevicesOptions{IncludeHttps: true, IncludeRelay: true, IncludeIPv6: true}, "includeHttps=1&includeIPv6=1&includeRelay=1"},
+	}
+
+	for _, test := range tests {
+		if query := test.options.query(); query != test.query {
+			t.Errorf("%+v: query() = %q, want %q", test.options, query, test.query)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Setting types.
+const (
+	SettingBool   = "bool"
+	SettingInt    = "int"
+	SettingDouble = "double"
+	SettingText   = "text"
+)
+
+// Setting is one of the server's preferences along with how to present
+// it.
+type Setting struct {
+	Id       string       `xml:"id,attr" json:"id"`
+	Label    string       `xml:"label,attr" json:"label"`
+	Summary  string       `xml:"summary,attr" json:"summary"`
+	Type     string       `xml:"type,attr" json:"type"`
+	Group    string       `xml:"group,attr" json:"group"`
+	Default  SettingValue `xml:"default,attr" json:"default"`
+	Value    SettingValue `xml:"value,attr" json:"value"`
+	Hidden   Bool         `xml:"hidden,attr" json:"hidden"`
+	Advanced Bool         `xml:"advanced,attr" json:"advanced"`
+
+	// EnumValues is the raw list of allowed values, see Options.
+	EnumValues string `xml:"enumValues,attr" json:"enumValues"`
+}
+
+// SettingValue is a setting's value in its text form, which is also what
+// SetPref takes. JSON responses send it as a string, number or boolean.
+type SettingValue string
+
+func (value *SettingValue) UnmarshalJSON(data []byte) error {
+	unquoted, err := strconv.Unquote(string(data))
+	if err != nil {
+		unquoted = string(data)
+	}
+	if unquoted == "null" {
+		unquoted = ""
+	}
+
+	*value = SettingValue(unquoted)
+	return nil
+}
+
+func (value SettingValue) Bool() bool {
+	parsed, _ := strconv.ParseBool(string(value))
+	return parsed
+}
+
+func (value SettingValue) Int() int {
+	parsed, _ := strconv.Atoi(string(value))
+	return parsed
+}
+
+func (value SettingValue) Float() float64 {
+	parsed, _ := strconv.ParseFloat(string(value), 64)
+	return parsed
+}
+
+// SettingOption is one of the values allowed for a setting.
+type SettingOption struct {
+	Value string
+	Label string
+}
+
+// Options lists the values allowed for the setting, or nil if it takes
+// any value of its Type.
+func (setting *Setting) Options() []SettingOption {
+	if len(setting.EnumValues) == 0 {
+		return nil
+	}
+
+	var options []SettingOption
+	for _, enumValue := range strings.Split(setting.EnumValues, "|") {
+		value, label, found := strings.Cut(enumValue, ":")
+		if !found {
+			label = value
+		}
+		options = append(options, SettingOption{Value: value, Label: label})
+	}
+	return options
+}
+
+type settingContainer struct {
+	Settings []*Setting `xml:"Setting" json:"Setting"`
+}
+
+// Prefs lists the server's preferences. Only the server's owner may see
+// (and change) them.
+func (server *PlexServer) Prefs(ctx context.Context) ([]*Setting, error) {
+	var container settingContainer
+	err := server.get(ctx, "/:/prefs", nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Settings, nil
+}
+
+// SetPref changes the preference with the given Setting Id.
+func (server *PlexServer) SetPref(ctx context.Context, id, value string) error {
+	return server.SetPrefs(ctx, map[string]string{id: value})
+}
+
+// SetPrefs changes several preferences at once.
+func (server *PlexServer) SetPrefs(ctx context.Context, values map[string]string) error {
+	params := url.Values{}
+	for id, value := range values {
+		params.Set(id, value)
+	}
+
+	return server.action(ctx, "PUT", "/:/prefs", params)
+}
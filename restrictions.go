@@ -0,0 +1,64 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ContentFilter limits what a user can see of one kind of library. Empty
+// fields don't restrict anything.
+type ContentFilter struct {
+	// ContentRatings and Labels, if set, are the only ones allowed.
+	ContentRatings []string
+	Labels         []string
+
+	ExcludedContentRatings []string
+	ExcludedLabels         []string
+}
+
+// String encodes the filter the way plex.tv stores it, e.g.
+// "contentRating=G%2CPG|label!=Horror".
+func (filter ContentFilter) String() string {
+	var terms []string
+	add := func(key string, values []string) {
+		if len(values) > 0 {
+			terms = append(terms, key+"="+strings.Join(values, "%2C"))
+		}
+	}
+
+	add("contentRating", filter.ContentRatings)
+	add("contentRating!", filter.ExcludedContentRatings)
+	add("label", filter.Labels)
+	add("label!", filter.ExcludedLabels)
+
+	return strings.Join(terms, "|")
+}
+
+// Restrictions are what a managed user (or a friend) is allowed to do on
+// the account's servers.
+type Restrictions struct {
+	AllowSync         bool
+	AllowCameraUpload bool
+	AllowChannels     bool
+
+	Movies     ContentFilter
+	Television ContentFilter
+	Music      ContentFilter
+}
+
+// SetRestrictions replaces the restrictions of a Plex Home managed user or
+// friend, given their HomeUser or SharedServer user id.
+func (user *UserAuthQuery) SetRestrictions(ctx context.Context, userId int, restrictions Restrictions) error {
+	params := url.Values{}
+	params.Set("allowSync", boolParam(restrictions.AllowSync))
+	params.Set("allowCameraUpload", boolParam(restrictions.AllowCameraUpload))
+	params.Set("allowChannels", boolParam(restrictions.AllowChannels))
+	params.Set("filterMovies", restrictions.Movies.String())
+	params.Set("filterTelevision", restrictions.Television.String())
+	params.Set("filterMusic", restrictions.Music.String())
+
+	uri := "https://plex.tv/api/friends/" + strconv.Itoa(userId) + "?" + params.Encode()
+	return user.plexTv(ctx, "PUT", uri, nil, nil)
+}
@@ -0,0 +1,71 @@
+package goplex
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy decides how often and how long to wait before trying a failed
+// request again. Waits double with every attempt, starting at BaseDelay and
+// capped at MaxDelay, unless the server sent a Retry-After.
+//
+// Rate limited (429) requests are always retried. Server errors and network
+// failures are only retried for idempotent methods, since a POST may
+// already have taken effect.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	// Zero or one disables retrying.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// retryDelay returns how long to wait before the next attempt, or false if
+// the request shouldn't be retried.
+func (policy *RetryPolicy) retryDelay(request *http.Request, attempt int, err error) (time.Duration, bool) {
+	if attempt >= policy.MaxAttempts || request.Context().Err() != nil {
+		return 0, false
+	}
+
+	var statusErr *HttpStatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case errors.Is(statusErr, ErrRateLimited):
+		case errors.Is(statusErr, ErrServerError) && isIdempotent(request.Method):
+		default:
+			return 0, false
+		}
+
+		if statusErr.RetryAfter > 0 {
+			if policy.MaxDelay > 0 && statusErr.RetryAfter > policy.MaxDelay {
+				// not worth waiting for, let the caller decide
+				return 0, false
+			}
+			return statusErr.RetryAfter, true
+		}
+	} else if !isIdempotent(request.Method) {
+		return 0, false
+	}
+
+	delay := policy.BaseDelay << (attempt - 1)
+	if policy.MaxDelay > 0 && (delay > policy.MaxDelay || delay <= 0) {
+		delay = policy.MaxDelay
+	}
+
+	return delay, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package goplex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 4, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	networkErr := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		method  string
+		attempt int
+		err     error
+		delay   time.Duration
+		retry   bool
+	}{
+		{"rate limited get", "GET", 1, &HttpStatusError{StatusCode: 429}, 100 * time.Millisecond, true},
+		{"rate limited post", "POST", 1, &HttpStatusError{StatusCode: 429}, 100 * time.Millisecond, true},
+		{"server error get", "GET", 2, &HttpStatusError{StatusCode: 503}, 200 * time.Millisecond, true},
+		{"server error post", "POST", 1, &HttpStatusError{StatusCode: 503}, 0, false},
+		{"network error put", "PUT", 1, networkErr, 100 * time.Millisecond, true},
+		{"network error post", "POST", 1, networkErr, 0, false},
+		{"not found", "GET", 1, &HttpStatusError{StatusCode: 404}, 0, false},
+		{"unauthorized", "GET", 1, &HttpStatusError{StatusCode: 401}, 0, false},
+		{"capped at max delay", "GET", 3, &HttpStatusError{StatusCode: 500}, 300 * time.Millisecond, true},
+		{"out of attempts", "GET", 4, &HttpStatusError{StatusCode: 500}, 0, false},
+		{"retry after", "GET", 1, &HttpStatusError{StatusCode: 429, RetryAfter: 250 * time.Millisecond}, 250 * time.Millisecond, true},
+		{"retry after too long", "GET", 1, &HttpStatusError{StatusCode: 429, RetryAfter: time.Second}, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, "http://plex.local/", nil)
+			delay, retry := policy.retryDelay(request, test.attempt, test.err)
+			if retry != test.retry || delay != test.delay {
+				t.Errorf("retryDelay() = %v, %v, want %v, %v", delay, retry, test.delay, test.retry)
+			}
+		})
+	}
+}
+
+func TestRetryDelayDisabled(t *testing.T) {
+	request := httptest.NewRequest("GET", "http://plex.local/", nil)
+	for _, policy := range []RetryPolicy{{}, {MaxAttempts: 1, BaseDelay: time.Millisecond}} {
+		if _, retry := policy.retryDelay(request, 1, &HttpStatusError{StatusCode: 429}); retry {
+			t.Errorf("%+v retried", policy)
+		}
+	}
+}
+
+func TestRetryDelayCanceled(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	request := httptest.NewRequest("GET", "http://plex.local/", nil).WithContext(ctx)
+	if _, retry := policy.retryDelay(request, 1, &HttpStatusError{StatusCode: 503}); retry {
+		t.Error("retried a canceled request")
+	}
+}
+
+func TestGetResponseRetries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetryPolicy(RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond}))
+
+	tests := []struct {
+		method   string
+		requests int32
+		ok       bool
+	}{
+		{"GET", 3, true},
+		{"POST", 1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+
+			request, err := client.newPlexRequest(context.Background(), test.method, server.URL, "", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := client.getResponse(request, http.StatusOK)
+			if response != nil {
+				response.Body.Close()
+			}
+
+			if ok := err == nil; ok != test.ok {
+				t.Errorf("got error %v", err)
+			}
+			if n := atomic.LoadInt32(&requests); n != test.requests {
+				t.Errorf("made %d requests, want %d", n, test.requests)
+			}
+		})
+	}
+}
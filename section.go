@@ -0,0 +1,124 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+)
+
+// Section is a handle on one library section of a server, for acting on
+// it. It is cheap to create and doesn't contact the server by itself.
+type Section struct {
+	Key string
+
+	server *PlexServer
+}
+
+// Section returns a handle on the library section with the given key, e.g.
+// the Key of a Directory returned by Sections.
+func (server *PlexServer) Section(key string) *Section {
+	return &Section{Key: key, server: server}
+}
+
+func (section *Section) path(suffix string) string {
+	return "/library/sections/" + url.PathEscape(section.Key) + suffix
+}
+
+// Scan looks for new, changed and removed files in all of the section's
+// folders. The scan runs in the background on the server.
+func (section *Section) Scan(ctx context.Context) error {
+	return section.server.action(ctx, "GET", section.path("/refresh"), nil)
+}
+
+// ScanPath only scans the given folder, which must be inside one of the
+// section's locations. This is much quicker than Scan after importing a
+// single movie or episode.
+func (section *Section) ScanPath(ctx context.Context, path string) error {
+	params := url.Values{}
+	params.Set("path", path)
+
+	return section.server.action(ctx, "GET", section.path("/refresh"), params)
+}
+
+// Refresh scans the section like Scan and, when force is set, also
+// re-downloads metadata for every item in it.
+func (section *Section) Refresh(ctx context.Context, force bool) error {
+	var params url.Values
+	if force {
+		params = url.Values{}
+		params.Set("force", "1")
+	}
+
+	return section.server.action(ctx, "GET", section.path("/refresh"), params)
+}
+
+// Section types, for SectionOptions.
+const (
+	SectionMovie  = "movie"
+	SectionShow   = "show"
+	SectionArtist = "artist"
+	SectionPhoto  = "photo"
+)
+
+// SectionOptions describe a library section for CreateSection and
+// Section.Edit.
+type SectionOptions struct {
+	Name string
+
+	// Type is one of the Section* constants. It can't be changed once the
+	// section exists.
+	Type     string
+	Agent    string
+	Scanner  string
+	Language string
+
+	// Locations are the folders, as seen by the server, that the section
+	// reads its media from.
+	Locations []string
+
+	// Prefs are the section's advanced settings, e.g. "enableCinemaTrailers".
+	Prefs map[string]string
+}
+
+func (options *SectionOptions) params() url.Values {
+	params := url.Values{}
+	set := func(key, value string) {
+		if len(value) > 0 {
+			params.Set(key, value)
+		}
+	}
+
+	set("name", options.Name)
+	set("type", options.Type)
+	set("agent", options.Agent)
+	set("scanner", options.Scanner)
+	set("language", options.Language)
+	for _, location := range options.Locations {
+		params.Add("location", location)
+	}
+	for key, value := range options.Prefs {
+		params.Set("prefs["+key+"]", value)
+	}
+
+	return params
+}
+
+// CreateSection adds a library section to the server, which then scans
+// its locations. Sections lists it once created.
+func (server *PlexServer) CreateSection(ctx context.Context, options SectionOptions) error {
+	return server.action(ctx, "POST", "/library/sections", options.params())
+}
+
+// Edit changes the section's name, agent, locations or prefs. Agent must
+// always be set; other empty fields are left as they are.
+func (section *Section) Edit(ctx context.Context, options SectionOptions) error {
+	params := options.params()
+	params.Del("type")
+
+	return section.server.action(ctx, "PUT", section.path(""), params)
+}
+
+// Delete removes the section and all of its metadata. The media files are
+// left alone.
+func (section *Section) Delete(ctx context.Context) error {
+	return section.server.action(ctx, "DELETE", section.path(""), nil)
+}
@@ -0,0 +1,191 @@
+package goplex
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PlexServer talks to a single Plex Media Server over one connection.
+type PlexServer struct {
+	Uri   string
+	Token string
+
+	// MachineIdentifier is the server's client identifier, known up front
+	// when the server was picked from the device list.
+	MachineIdentifier string
+
+	client *Client
+}
+
+func NewPlexServer(uri, token string) *PlexServer {
+	return DefaultClient.NewPlexServer(uri, token)
+}
+
+func (client *Client) NewPlexServer(uri, token string) *PlexServer {
+	return &PlexServer{
+		Uri:    strings.TrimRight(uri, "/"),
+		Token:  token,
+		client: client,
+	}
+}
+
+// Server returns the media server reachable through the given connection,
+// e.g. one picked by GetBestConnection. The device's access token is used,
+// which also works for servers shared with the account.
+func (device *PlexDevice) Server(connection *PlexDeviceConnection) *PlexServer {
+	server := clientOrDefault(device.client).NewPlexServer(connection.Uri, device.AccessToken)
+	server.MachineIdentifier = device.ClientIdentifier
+	return server
+}
+
+func (server *PlexServer) newRequest(ctx context.Context, method, path string, params url.Values, body io.Reader) (*http.Request, error) {
+	uri := server.Uri + path
+	if len(params) > 0 {
+		if strings.Contains(path, "?") {
+			uri += "&" + params.Encode()
+		} else {
+			uri += "?" + params.Encode()
+		}
+	}
+
+	client := clientOrDefault(server.client)
+	request, err := client.newPlexRequest(ctx, method, uri, server.Token, body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", client.format.mimeType())
+
+	return request, nil
+}
+
+// setPaging asks for size items starting at start. A size of 0 leaves
+// paging up to the server.
+func setPaging(params url.Values, start, size int) {
+	if size <= 0 {
+		return
+	}
+	params.Set("X-Plex-Container-Start", strconv.Itoa(start))
+	params.Set("X-Plex-Container-Size", strconv.Itoa(size))
+}
+
+// get fetches path from the server and decodes the response into v.
+func (server *PlexServer) get(ctx context.Context, path string, params url.Values, v interface{}) error {
+	return server.fetch(ctx, "GET", path, params, v)
+}
+
+// fetch sends a request and decodes the response into v.
+func (server *PlexServer) fetch(ctx context.Context, method, path string, params url.Values, v interface{}) error {
+	request, err := server.newRequest(ctx, method, path, params, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := clientOrDefault(server.client).getResponse(request, http.StatusOK)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	return unmarshalResponse(response, v)
+}
+
+// action sends a request that changes something on the server and
+// doesn't return anything of interest.
+func (server *PlexServer) action(ctx context.Context, method, path string, params url.Values) error {
+	request, err := server.newRequest(ctx, method, path, params, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := clientOrDefault(server.client).getResponse(request, http.StatusOK, http.StatusNoContent)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	return err
+}
+
+// Identity asks the server for its machine identifier, filling in
+// MachineIdentifier. It works without a token.
+func (server *PlexServer) Identity(ctx context.Context) (string, error) {
+	var container MediaContainer
+	err := server.get(ctx, "/identity", nil, &container)
+	if err != nil {
+		return "", err
+	}
+
+	server.MachineIdentifier = container.MachineIdentifier
+	return server.MachineIdentifier, nil
+}
+
+// libraryUri builds the server:// uri play queues and playlists use to
+// refer to library content, e.g. for path /library/metadata/123.
+func (server *PlexServer) libraryUri(ctx context.Context, path string) (string, error) {
+	machineIdentifier := server.MachineIdentifier
+	if len(machineIdentifier) == 0 {
+		var err error
+		machineIdentifier, err = server.Identity(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "server://" + machineIdentifier + "/com.plexapp.plugins.library" + path, nil
+}
+
+// Sections lists the server's libraries.
+func (server *PlexServer) Sections(ctx context.Context) ([]*Directory, error) {
+	var container MediaContainer
+	err := server.get(ctx, "/library/sections", nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Directories, nil
+}
+
+// SectionContents lists everything in the library with the given key.
+func (server *PlexServer) SectionContents(ctx context.Context, sectionKey string) (*MediaContainer, error) {
+	var container MediaContainer
+	err := server.get(ctx, "/library/sections/"+url.PathEscape(sectionKey)+"/all", nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
+
+// Metadata fetches the full details of a single item. Movies and episodes
+// come back in Videos, shows, seasons and albums in Directories.
+func (server *PlexServer) Metadata(ctx context.Context, ratingKey string) (*MediaContainer, error) {
+	return server.MetadataWithOptions(ctx, ratingKey, MetadataOptions{})
+}
+
+// MetadataOptions asks for extra details Metadata leaves out.
+type MetadataOptions struct {
+	IncludeChapters bool
+	IncludeMarkers  bool
+}
+
+func (server *PlexServer) MetadataWithOptions(ctx context.Context, ratingKey string, options MetadataOptions) (*MediaContainer, error) {
+	params := url.Values{}
+	if options.IncludeChapters {
+		params.Set("includeChapters", "1")
+	}
+	if options.IncludeMarkers {
+		params.Set("includeMarkers", "1")
+	}
+
+	var container MediaContainer
+	err := server.get(ctx, "/library/metadata/"+url.PathEscape(ratingKey), params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
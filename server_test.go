@@ -0,0 +1,43 @@
+package goplex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// recordingServer is a media server that answers every request with the
+// same body and remembers the requests it got.
+type recordingServer struct {
+	server *httptest.Server
+
+	lock     sync.Mutex
+	requests []*http.Request
+}
+
+func newRecordingServer(t *testing.T, contentType, body string) (*recordingServer, *PlexServer) {
+	recorder := &recordingServer{}
+	recorder.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder.lock.Lock()
+		recorder.requests = append(recorder.requests, r)
+		recorder.lock.Unlock()
+
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(recorder.server.Close)
+
+	return recorder, NewClient().NewPlexServer(recorder.server.URL, "secret")
+}
+
+// last returns the most recent request, failing the test if there was none.
+func (recorder *recordingServer) last(t *testing.T) *http.Request {
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+
+	if len(recorder.requests) == 0 {
+		t.Fatal("no request made")
+	}
+	return recorder.requests[len(recorder.requests)-1]
+}
@@ -0,0 +1,138 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// SessionUser is the account playing a session.
+type SessionUser struct {
+	Id    int    `xml:"id,attr" json:"id,string"`
+	Title string `xml:"title,attr" json:"title"`
+	Thumb string `xml:"thumb,attr" json:"thumb"`
+}
+
+// SessionPlayer is the app or device a session is playing on.
+type SessionPlayer struct {
+	MachineIdentifier   string `xml:"machineIdentifier,attr" json:"machineIdentifier"`
+	Title               string `xml:"title,attr" json:"title"`
+	Product             string `xml:"product,attr" json:"product"`
+	Platform            string `xml:"platform,attr" json:"platform"`
+	PlatformVersion     string `xml:"platformVersion,attr" json:"platformVersion"`
+	Device              string `xml:"device,attr" json:"device"`
+	Profile             string `xml:"profile,attr" json:"profile"`
+	Version             string `xml:"version,attr" json:"version"`
+	Vendor              string `xml:"vendor,attr" json:"vendor"`
+	Address             string `xml:"address,attr" json:"address"`
+	RemotePublicAddress string `xml:"remotePublicAddress,attr" json:"remotePublicAddress"`
+	State               string `xml:"state,attr" json:"state"`
+	UserId              int    `xml:"userID,attr" json:"userID"`
+	IsLocal             Bool   `xml:"local,attr" json:"local"`
+	IsRelayed           Bool   `xml:"relayed,attr" json:"relayed"`
+	IsSecure            Bool   `xml:"secure,attr" json:"secure"`
+}
+
+// PlaybackSession identifies a session and the bandwidth the server set
+// aside for it, in kbps.
+type PlaybackSession struct {
+	Id        string `xml:"id,attr" json:"id"`
+	Bandwidth int    `xml:"bandwidth,attr" json:"bandwidth"`
+	Location  string `xml:"location,attr" json:"location"`
+}
+
+// TranscodeSession describes what the server is doing to a stream that
+// can't be played directly.
+type TranscodeSession struct {
+	Key                     string  `xml:"key,attr" json:"key"`
+	Progress                float64 `xml:"progress,attr" json:"progress"`
+	Speed                   float64 `xml:"speed,attr" json:"speed"`
+	Duration                int64   `xml:"duration,attr" json:"duration"`
+	Protocol                string  `xml:"protocol,attr" json:"protocol"`
+	Container               string  `xml:"container,attr" json:"container"`
+	VideoDecision           string  `xml:"videoDecision,attr" json:"videoDecision"`
+	AudioDecision           string  `xml:"audioDecision,attr" json:"audioDecision"`
+	SubtitleDecision        string  `xml:"subtitleDecision,attr" json:"subtitleDecision"`
+	SourceVideoCodec        string  `xml:"sourceVideoCodec,attr" json:"sourceVideoCodec"`
+	SourceAudioCodec        string  `xml:"sourceAudioCodec,attr" json:"sourceAudioCodec"`
+	VideoCodec              string  `xml:"videoCodec,attr" json:"videoCodec"`
+	AudioCodec              string  `xml:"audioCodec,attr" json:"audioCodec"`
+	AudioChannels           int     `xml:"audioChannels,attr" json:"audioChannels"`
+	Width                   int     `xml:"width,attr" json:"width"`
+	Height                  int     `xml:"height,attr" json:"height"`
+	IsThrottled             Bool    `xml:"throttled,attr" json:"throttled"`
+	IsComplete              Bool    `xml:"complete,attr" json:"complete"`
+	TranscodeHwRequested    Bool    `xml:"transcodeHwRequested,attr" json:"transcodeHwRequested"`
+	TranscodeHwDecoding     string  `xml:"transcodeHwDecoding,attr" json:"transcodeHwDecoding"`
+	TranscodeHwEncoding     string  `xml:"transcodeHwEncoding,attr" json:"transcodeHwEncoding"`
+	TranscodeHwFullPipeline Bool    `xml:"transcodeHwFullPipeline,attr" json:"transcodeHwFullPipeline"`
+}
+
+type transcodeSessionContainer struct {
+	TranscodeSessions []*TranscodeSession `xml:"TranscodeSession" json:"TranscodeSession"`
+}
+
+// TranscodeSessions lists the server's running transcodes, including
+// those for downloads and sync that don't show up in Sessions.
+func (server *PlexServer) TranscodeSessions(ctx context.Context) ([]*TranscodeSession, error) {
+	var container transcodeSessionContainer
+	err := server.get(ctx, "/transcode/sessions", nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.TranscodeSessions, nil
+}
+
+// StopTranscodeSession kills the transcoder of the session with the given
+// Key. The player sees its stream end with an error.
+func (server *PlexServer) StopTranscodeSession(ctx context.Context, key string) error {
+	return server.action(ctx, "DELETE", "/transcode/sessions/"+url.PathEscape(key), nil)
+}
+
+// Sessions lists what is playing on the server right now: the item, who is
+// watching it, on which player and whether it's being transcoded. Music
+// and video sessions are returned together.
+func (server *PlexServer) Sessions(ctx context.Context) ([]*Video, error) {
+	var container MediaContainer
+	err := server.get(ctx, "/status/sessions", nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(container.Videos, container.Tracks...), nil
+}
+
+// TerminateSession stops a playback session, showing reason to the viewer.
+// sessionId is the Session.Id of an item returned by Sessions. The server
+// owner needs a Plex Pass for this.
+func (server *PlexServer) TerminateSession(ctx context.Context, sessionId, reason string) error {
+	params := url.Values{}
+	params.Set("sessionId", sessionId)
+	params.Set("reason", reason)
+
+	return server.action(ctx, "GET", "/status/sessions/terminate", params)
+}
+
+// ReportTimeline tells the server how far playback of an item got, as a
+// player does every few seconds while playing. state is one of the State*
+// constants, timeMs the position and durationMs the item's length (its
+// Duration), both in milliseconds. The server uses this for resume points,
+// Continue Watching and, once most of the item has been played, marking it
+// watched. That last part needs the duration; pass 0 if it isn't known.
+//
+// Reports are tied to the Client's X-Plex-Client-Identifier, so keep it
+// stable for the duration of a playback.
+func (server *PlexServer) ReportTimeline(ctx context.Context, ratingKey, state string, timeMs, durationMs int64) error {
+	params := url.Values{}
+	params.Set("ratingKey", ratingKey)
+	params.Set("key", "/library/metadata/"+ratingKey)
+	params.Set("identifier", libraryIdentifier)
+	params.Set("state", state)
+	params.Set("time", strconv.FormatInt(timeMs, 10))
+	if durationMs > 0 {
+		params.Set("duration", strconv.FormatInt(durationMs, 10))
+	}
+
+	return server.action(ctx, "GET", "/:/timeline", params)
+}
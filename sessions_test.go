@@ -0,0 +1,36 @@
+package goplex
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReportTimeline(t *testing.T) {
+	tests := []struct {
+		name       string
+		durationMs int64
+		query      string
+	}{
+		{"with duration", 5400000, "duration=5400000&identifier=com.plexapp.plugins.library&key=%2Flibrary%2Fmetadata%2F42&ratingKey=42&state=playing&time=60000"},
+		{"without duration", 0, "identifier=com.plexapp.plugins.library&key=%2Flibrary%2Fmetadata%2F42&ratingKey=42&state=playing&time=60000"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder, server := newRecordingServer(t, "application/xml", "")
+
+			err := server.ReportTimeline(context.Background(), "42", StatePlaying, 60000, test.durationMs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			request := recorder.last(t)
+			if request.Method != "GET" || request.URL.Path != "/:/timeline" {
+				t.Errorf("got %s %s", request.Method, request.URL.Path)
+			}
+			if request.URL.RawQuery != test.query {
+				t.Errorf("query %q, want %q", request.URL.RawQuery, test.query)
+			}
+		})
+	}
+}
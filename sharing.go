@@ -0,0 +1,131 @@
+package goplex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// SharedServer is a user's access to one of the account's servers.
+type SharedServer struct {
+	Id                int    `xml:"id,attr"`
+	UserId            int    `xml:"userID,attr"`
+	Username          string `xml:"username,attr"`
+	Email             string `xml:"email,attr"`
+	AccessToken       string `xml:"accessToken,attr"`
+	InvitedAt         int64  `xml:"invitedAt,attr"`
+	AcceptedAt        int64  `xml:"acceptedAt,attr"`
+	AllowSync         bool   `xml:"allowSync,attr"`
+	AllowCameraUpload bool   `xml:"allowCameraUpload,attr"`
+	AllowChannels     bool   `xml:"allowChannels,attr"`
+	FilterMovies      string `xml:"filterMovies,attr"`
+	FilterTelevision  string `xml:"filterTelevision,attr"`
+	FilterMusic       string `xml:"filterMusic,attr"`
+
+	Sections []*SharedSection `xml:"Section"`
+}
+
+// SharedSection is a library section as plex.tv knows it. Its Id is not
+// the section's key on the server, and is what sharing takes.
+type SharedSection struct {
+	Id     int    `xml:"id,attr"`
+	Key    string `xml:"key,attr"`
+	Title  string `xml:"title,attr"`
+	Type   string `xml:"type,attr"`
+	Shared bool   `xml:"shared,attr"`
+}
+
+// ShareOptions are the restrictions on a share. The filters are plex.tv
+// filter strings, as built by ContentFilter.String.
+type ShareOptions struct {
+	AllowSync         bool
+	AllowCameraUpload bool
+	AllowChannels     bool
+	FilterMovies      string
+	FilterTelevision  string
+	FilterMusic       string
+}
+
+func (options *ShareOptions) settings() map[string]string {
+	return map[string]string{
+		"allowSync":         boolParam(options.AllowSync),
+		"allowCameraUpload": boolParam(options.AllowCameraUpload),
+		"allowChannels":     boolParam(options.AllowChannels),
+		"filterMovies":      options.FilterMovies,
+		"filterTelevision":  options.FilterTelevision,
+		"filterMusic":       options.FilterMusic,
+	}
+}
+
+func sharedServersUri(machineIdentifier string) string {
+	return "https://plex.tv/api/servers/" + url.PathEscape(machineIdentifier) + "/shared_servers"
+}
+
+// SharedServers lists who the server with the given machine identifier is
+// shared with, including pending invites.
+func (user *UserAuthQuery) SharedServers(ctx context.Context, machineIdentifier string) ([]*SharedServer, error) {
+	var q struct {
+		SharedServers []*SharedServer `xml:"SharedServer"`
+	}
+	err := user.plexTv(ctx, "GET", sharedServersUri(machineIdentifier), nil, &q)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.SharedServers, nil
+}
+
+// ServerSections lists the server's library sections with the ids
+// sharing uses.
+func (user *UserAuthQuery) ServerSections(ctx context.Context, machineIdentifier string) ([]*SharedSection, error) {
+	var q struct {
+		Servers []struct {
+			Sections []*SharedSection `xml:"Section"`
+		} `xml:"Server"`
+	}
+	err := user.plexTv(ctx, "GET", "https://plex.tv/api/servers/"+url.PathEscape(machineIdentifier), nil, &q)
+	if err != nil {
+		return nil, err
+	}
+	if len(q.Servers) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return q.Servers[0].Sections, nil
+}
+
+// Share gives the plex.tv user with the given username or email access to
+// some of the server's sections. Someone who isn't a friend yet is sent an
+// invite first. sectionIds are SharedSection ids, see ServerSections.
+func (user *UserAuthQuery) Share(ctx context.Context, machineIdentifier, usernameOrEmail string, sectionIds []int, options ShareOptions) error {
+	body := map[string]interface{}{
+		"server_id": machineIdentifier,
+		"shared_server": map[string]interface{}{
+			"library_section_ids": sectionIds,
+			"invited_email":       usernameOrEmail,
+		},
+		"sharing_settings": options.settings(),
+	}
+
+	return user.plexTv(ctx, "POST", sharedServersUri(machineIdentifier), body, nil)
+}
+
+// UpdateShare replaces the sections and restrictions of an existing share.
+func (user *UserAuthQuery) UpdateShare(ctx context.Context, machineIdentifier string, sharedServerId int, sectionIds []int, options ShareOptions) error {
+	body := map[string]interface{}{
+		"server_id": machineIdentifier,
+		"shared_server": map[string]interface{}{
+			"library_section_ids": sectionIds,
+		},
+		"sharing_settings": options.settings(),
+	}
+
+	uri := fmt.Sprintf("%s/%d", sharedServersUri(machineIdentifier), sharedServerId)
+	return user.plexTv(ctx, "PUT", uri, body, nil)
+}
+
+// Unshare takes away a user's access to the server.
+func (user *UserAuthQuery) Unshare(ctx context.Context, machineIdentifier string, sharedServerId int) error {
+	uri := fmt.Sprintf("%s/%d", sharedServersUri(machineIdentifier), sharedServerId)
+	return user.plexTv(ctx, "DELETE", uri, nil, nil)
+}
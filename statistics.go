@@ -0,0 +1,125 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Statistics timespans, the size of the buckets statistics are grouped
+// into.
+const (
+	TimespanMonths  = 1
+	TimespanWeeks   = 2
+	TimespanDays    = 3
+	TimespanHours   = 4
+	TimespanSeconds = 6
+)
+
+// StatisticsDevice is a player that shows up in the server's statistics.
+type StatisticsDevice struct {
+	Id               int    `xml:"id,attr" json:"id"`
+	Name             string `xml:"name,attr" json:"name"`
+	Platform         string `xml:"platform,attr" json:"platform"`
+	ClientIdentifier string `xml:"clientIdentifier,attr" json:"clientIdentifier"`
+	CreatedAt        int64  `xml:"createdAt,attr" json:"createdAt"`
+}
+
+// StatisticsAccount is a user that shows up in the server's statistics.
+type StatisticsAccount struct {
+	Id    int    `xml:"id,attr" json:"id"`
+	Key   string `xml:"key,attr" json:"key"`
+	Name  string `xml:"name,attr" json:"name"`
+	Thumb string `xml:"thumb,attr" json:"thumb"`
+}
+
+// BandwidthBucket is how much one account sent to one device over one
+// timespan, split into local (Lan) and remote buckets.
+type BandwidthBucket struct {
+	AccountId int   `xml:"accountID,attr" json:"accountID"`
+	DeviceId  int   `xml:"deviceID,attr" json:"deviceID"`
+	Timespan  int   `xml:"timespan,attr" json:"timespan"`
+	At        int64 `xml:"at,attr" json:"at"`
+	Lan       Bool  `xml:"lan,attr" json:"lan"`
+	Bytes     int64 `xml:"bytes,attr" json:"bytes"`
+}
+
+// BandwidthStatistics holds the bandwidth buckets along with the accounts
+// and devices they refer to by id.
+type BandwidthStatistics struct {
+	Devices  []*StatisticsDevice  `xml:"Device" json:"Device"`
+	Accounts []*StatisticsAccount `xml:"Account" json:"Account"`
+	Buckets  []*BandwidthBucket   `xml:"StatisticsBandwidth" json:"StatisticsBandwidth"`
+}
+
+// BandwidthOptions narrows down Bandwidth. Zero values don't filter.
+type BandwidthOptions struct {
+	// Timespan is one of the Timespan* constants, TimespanSeconds by
+	// default.
+	Timespan  int
+	AccountId int
+	DeviceId  int
+	Since     time.Time
+}
+
+// Bandwidth returns how much the server streamed, per account and device.
+// The server owner needs a Plex Pass for this.
+func (server *PlexServer) Bandwidth(ctx context.Context, options BandwidthOptions) (*BandwidthStatistics, error) {
+	params := url.Values{}
+	timespan := options.Timespan
+	if timespan == 0 {
+		timespan = TimespanSeconds
+	}
+	params.Set("timespan", strconv.Itoa(timespan))
+	if options.AccountId != 0 {
+		params.Set("accountID", strconv.Itoa(options.AccountId))
+	}
+	if options.DeviceId != 0 {
+		params.Set("deviceID", strconv.Itoa(options.DeviceId))
+	}
+	if !options.Since.IsZero() {
+		params.Set("at>", strconv.FormatInt(options.Since.Unix(), 10))
+	}
+
+	var statistics BandwidthStatistics
+	err := server.get(ctx, "/statistics/bandwidth", params, &statistics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &statistics, nil
+}
+
+// ResourceSample is the server's CPU and memory use over one timespan, in
+// percent. Host is the whole machine, Process the media server alone.
+type ResourceSample struct {
+	Timespan                 int     `xml:"timespan,attr" json:"timespan"`
+	At                       int64   `xml:"at,attr" json:"at"`
+	HostCpuUtilization       float64 `xml:"hostCpuUtilization,attr" json:"hostCpuUtilization"`
+	ProcessCpuUtilization    float64 `xml:"processCpuUtilization,attr" json:"processCpuUtilization"`
+	HostMemoryUtilization    float64 `xml:"hostMemoryUtilization,attr" json:"hostMemoryUtilization"`
+	ProcessMemoryUtilization float64 `xml:"processMemoryUtilization,attr" json:"processMemoryUtilization"`
+}
+
+type resourceContainer struct {
+	Samples []*ResourceSample `xml:"StatisticsResources" json:"StatisticsResources"`
+}
+
+// Resources returns the server's recent CPU and memory use, oldest first.
+// timespan is one of the Timespan* constants, TimespanSeconds if 0.
+func (server *PlexServer) Resources(ctx context.Context, timespan int) ([]*ResourceSample, error) {
+	if timespan == 0 {
+		timespan = TimespanSeconds
+	}
+	params := url.Values{}
+	params.Set("timespan", strconv.Itoa(timespan))
+
+	var container resourceContainer
+	err := server.get(ctx, "/statistics/resources", params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Samples, nil
+}
@@ -0,0 +1,199 @@
+package goplex
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ErrStopStream can be returned by a StreamFunc to stop reading the rest
+// of the response without Stream returning an error.
+var ErrStopStream = errors.New("stop stream")
+
+// StreamFunc is called for each item of a streamed listing, with either
+// directory (shows, seasons, artists, albums) or video (movies, episodes,
+// tracks) set.
+type StreamFunc func(directory *Directory, video *Video) error
+
+// Stream lists the section's items matching filter like All, but decodes
+// the response as it arrives and hands each item to visit instead of
+// keeping them all in memory. The returned MediaContainer has the
+// response's attributes but no items.
+func (section *Section) Stream(ctx context.Context, filter *Filter, visit StreamFunc) (*MediaContainer, error) {
+	path := section.path("/all")
+	if filter != nil {
+		if query := filter.encode(); len(query) > 0 {
+			path += "?" + query
+		}
+	}
+
+	return section.server.stream(ctx, path, nil, visit)
+}
+
+func (server *PlexServer) stream(ctx context.Context, path string, params url.Values, visit StreamFunc) (*MediaContainer, error) {
+	request, err := server.newRequest(ctx, "GET", path, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := clientOrDefault(server.client).getResponse(request, http.StatusOK)
+	if response != nil {
+		defer response.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var container MediaContainer
+	if isJsonResponse(response) {
+		err = streamJson(response.Body, &container, visit)
+	} else {
+		err = streamXml(response.Body, &container, visit)
+	}
+	if err != nil && err != ErrStopStream {
+		return nil, err
+	}
+	return &container, nil
+}
+
+// tokens replays a fixed list of tokens, to decode a start element's
+// attributes without reading its children.
+type tokens []xml.Token
+
+func (t *tokens) Token() (xml.Token, error) {
+	if len(*t) == 0 {
+		return nil, io.EOF
+	}
+	token := (*t)[0]
+	*t = (*t)[1:]
+	return token, nil
+}
+
+func streamXml(body io.Reader, container *MediaContainer, visit StreamFunc) error {
+	decoder := xml.NewDecoder(body)
+	root := true
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if root {
+			root = false
+			attributes := tokens{start, start.End()}
+			err = xml.NewTokenDecoder(&attributes).Decode(container)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch start.Name.Local {
+		case "Directory":
+			var directory Directory
+			err = decoder.DecodeElement(&directory, &start)
+			if err == nil {
+				err = visit(&directory, nil)
+			}
+		case "Video", "Track":
+			var video Video
+			err = decoder.DecodeElement(&video, &start)
+			if err == nil {
+				err = visit(nil, &video)
+			}
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func streamJson(body io.Reader, container *MediaContainer, visit StreamFunc) error {
+	decoder := json.NewDecoder(body)
+
+	// {"MediaContainer": {
+	for _, expected := range []interface{}{json.Delim('{'), "MediaContainer", json.Delim('{')} {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if token != expected {
+			return errors.New("unexpected JSON response")
+		}
+	}
+
+	attributes := map[string]json.RawMessage{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+
+		switch key {
+		case "Directory", "Metadata":
+			err = streamJsonItems(decoder, key == "Directory", visit)
+		default:
+			var value json.RawMessage
+			err = decoder.Decode(&value)
+			attributes[key] = value
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	encoded, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, container)
+}
+
+func streamJsonItems(decoder *json.Decoder, directories bool, visit StreamFunc) error {
+	_, err := decoder.Token() // [
+	if err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		var raw json.RawMessage
+		err = decoder.Decode(&raw)
+		if err != nil {
+			return err
+		}
+
+		var directory *Directory
+		var video *Video
+		if directories {
+			directory = &Directory{}
+			err = json.Unmarshal(raw, directory)
+		} else {
+			directory, video, err = unmarshalJsonMetadata(raw)
+		}
+		if err == nil {
+			err = visit(directory, video)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = decoder.Token() // ]
+	return err
+}
@@ -0,0 +1,77 @@
+package goplex
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// a section listing mixing items that XML sends as Directory and as Video
+const mixedJsonListing = `{"MediaContainer":{"size":5,"totalSize":5,"librarySectionTitle":"Mixed","Metadata":[
+	{"ratingKey":"1","type":"show","title":"The Show"},
+	{"ratingKey":"2","type":"season","title":"Season 1"},
+	{"ratingKey":"3","type":"episode","title":"Pilot"},
+	{"ratingKey":"4","type":"album","title":"The Album"},
+	{"ratingKey":"5","type":"movie","title":"The Movie"}
+]}}`
+
+var mixedJsonKinds = map[string]string{
+	"1": "directory",
+	"2": "directory",
+	"3": "video",
+	"4": "directory",
+	"5": "video",
+}
+
+func TestJsonListingTypesMatch(t *testing.T) {
+	_, server := newRecordingServer(t, "application/json", mixedJsonListing)
+	section := server.Section("1")
+	ctx := context.Background()
+
+	container, err := section.All(ctx, nil, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := map[string]string{}
+	for _, directory := range container.Directories {
+		all[directory.RatingKey] = "directory"
+	}
+	for _, video := range container.Videos {
+		all[video.RatingKey] = "video"
+	}
+
+	streamed := map[string]string{}
+	container, err = section.Stream(ctx, nil, func(directory *Directory, video *Video) error {
+		if directory != nil {
+			streamed[directory.RatingKey] = "directory"
+		} else {
+			streamed[video.RatingKey] = "video"
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if container.LibrarySectionTitle != "Mixed" {
+		t.Errorf("streamed container title %q", container.LibrarySectionTitle)
+	}
+
+	iterated := map[string]string{}
+	iterator := section.Iterate(nil, 0)
+	for iterator.Next(ctx) {
+		if directory := iterator.Directory(); directory != nil {
+			iterated[directory.RatingKey] = "directory"
+		} else {
+			iterated[iterator.Video().RatingKey] = "video"
+		}
+	}
+	if err := iterator.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, kinds := range map[string]map[string]string{"All": all, "Stream": streamed, "Iterate": iterated} {
+		if !reflect.DeepEqual(kinds, mixedJsonKinds) {
+			t.Errorf("%s returned %v, want %v", name, kinds, mixedJsonKinds)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package goplex
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// streams returns the video's streams of the given StreamType* across all
+// of its media and parts.
+func (video *Video) streams(streamType int) []*Stream {
+	var streams []*Stream
+	for _, media := range video.Media {
+		for _, part := range media.Parts {
+			for _, stream := range part.Streams {
+				if stream.StreamType == streamType {
+					streams = append(streams, stream)
+				}
+			}
+		}
+	}
+	return streams
+}
+
+// SubtitleStreams lists the subtitles the video already has, embedded or
+// sidecar. Streams are only included when the video was fetched with
+// Metadata.
+func (video *Video) SubtitleStreams() []*Stream {
+	return video.streams(StreamTypeSubtitle)
+}
+
+// AudioStreams lists the video's audio tracks. Streams are only included
+// when the video was fetched with Metadata.
+func (video *Video) AudioStreams() []*Stream {
+	return video.streams(StreamTypeAudio)
+}
+
+func (server *PlexServer) selectStream(ctx context.Context, partId int, param string, streamId int, allParts bool) error {
+	params := url.Values{}
+	params.Set(param, strconv.Itoa(streamId))
+	if allParts {
+		params.Set("allParts", "1")
+	}
+
+	return server.action(ctx, "PUT", fmt.Sprintf("/library/parts/%d", partId), params)
+}
+
+// SelectAudioStream makes the audio stream with the given id the default
+// when playing the part. With allParts set the same language is picked
+// for the other episodes of the show as well.
+func (server *PlexServer) SelectAudioStream(ctx context.Context, partId, streamId int, allParts bool) error {
+	return server.selectStream(ctx, partId, "audioStreamID", streamId, allParts)
+}
+
+// SelectSubtitleStream makes the subtitle stream with the given id the
+// default when playing the part, or turns subtitles off for a streamId of
+// 0. allParts works as for SelectAudioStream.
+func (server *PlexServer) SelectSubtitleStream(ctx context.Context, partId, streamId int, allParts bool) error {
+	return server.selectStream(ctx, partId, "subtitleStreamID", streamId, allParts)
+}
@@ -0,0 +1,61 @@
+package goplex
+
+import (
+	"context"
+	"io"
+	"net/url"
+)
+
+// SubtitleSearchOptions narrows down SearchSubtitles.
+type SubtitleSearchOptions struct {
+	// Language is a two or three letter code, e.g. "en" or "fre".
+	Language        string
+	HearingImpaired bool
+	Forced          bool
+}
+
+type streamContainer struct {
+	Streams []*Stream `xml:"Stream" json:"Stream"`
+}
+
+// SearchSubtitles asks the server's subtitle agents (e.g. OpenSubtitles)
+// for subtitles matching the item.
+func (item *Item) SearchSubtitles(ctx context.Context, options SubtitleSearchOptions) ([]*Stream, error) {
+	params := url.Values{}
+	params.Set("language", options.Language)
+	params.Set("hearingImpaired", boolParam(options.HearingImpaired))
+	params.Set("forced", boolParam(options.Forced))
+
+	var container streamContainer
+	err := item.server.get(ctx, item.path("/subtitles"), params, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return container.Streams, nil
+}
+
+// DownloadSubtitle has the server download subtitle, one of the results of
+// SearchSubtitles, and add it to the item.
+func (item *Item) DownloadSubtitle(ctx context.Context, subtitle *Stream) error {
+	params := url.Values{}
+	params.Set("key", subtitle.Key)
+	params.Set("codec", subtitle.Codec)
+	params.Set("language", subtitle.LanguageCode)
+	params.Set("providerTitle", subtitle.ProviderTitle)
+	params.Set("hearingImpaired", boolParam(bool(subtitle.HearingImpaired)))
+	params.Set("forced", boolParam(bool(subtitle.Forced)))
+
+	return item.server.action(ctx, "PUT", item.path("/subtitles"), params)
+}
+
+// UploadSubtitle adds a sidecar subtitle file to the item. language is a
+// two or three letter code and format the file's extension, e.g. "srt" or
+// "ass".
+func (item *Item) UploadSubtitle(ctx context.Context, subtitle io.Reader, language, format string) error {
+	params := url.Values{}
+	params.Set("language", language)
+	params.Set("format", format)
+
+	return item.upload(ctx, "/subtitles", params, subtitle)
+}
@@ -0,0 +1,55 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+)
+
+// UpdateStatus is what the server knows about available updates of
+// itself.
+type UpdateStatus struct {
+	CanInstall  Bool   `xml:"canInstall,attr" json:"canInstall"`
+	CheckedAt   int64  `xml:"checkedAt,attr" json:"checkedAt"`
+	DownloadUrl string `xml:"downloadURL,attr" json:"downloadURL"`
+	Status      int    `xml:"status,attr" json:"status"`
+
+	Releases []*Release `xml:"Release" json:"Release"`
+}
+
+type Release struct {
+	Key         string `xml:"key,attr" json:"key"`
+	Version     string `xml:"version,attr" json:"version"`
+	Added       string `xml:"added,attr" json:"added"`
+	Fixed       string `xml:"fixed,attr" json:"fixed"`
+	DownloadUrl string `xml:"downloadURL,attr" json:"downloadURL"`
+	State       string `xml:"state,attr" json:"state"`
+}
+
+// UpdateStatus returns the result of the server's last update check.
+func (server *PlexServer) UpdateStatus(ctx context.Context) (*UpdateStatus, error) {
+	var status UpdateStatus
+	err := server.get(ctx, "/updater/status", nil, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// CheckForUpdates has the server look for a new version, and download it
+// straight away if download is set. The result shows up in UpdateStatus.
+func (server *PlexServer) CheckForUpdates(ctx context.Context, download bool) error {
+	params := url.Values{}
+	params.Set("download", boolParam(download))
+
+	return server.action(ctx, "PUT", "/updater/check", params)
+}
+
+// ApplyUpdate installs the downloaded update, restarting the server, or
+// schedules it for the maintenance window if tonight is set.
+func (server *PlexServer) ApplyUpdate(ctx context.Context, tonight bool) error {
+	params := url.Values{}
+	params.Set("tonight", boolParam(tonight))
+
+	return server.action(ctx, "PUT", "/updater/apply", params)
+}
@@ -0,0 +1,69 @@
+package goplex
+
+import (
+	"context"
+	"net/url"
+	"path"
+)
+
+const metadataProviderUri = "https://metadata.provider.plex.tv"
+
+// discoverRatingKey turns a Plex guid such as plex://movie/5d7768...
+// into the rating key the metadata provider uses for it.
+func discoverRatingKey(guid string) string {
+	return path.Base(guid)
+}
+
+// Watchlist lists the movies and shows on the user's Watchlist, starting
+// at start. A size of 0 lets plex.tv decide how many to return. Items are
+// described by Plex's own metadata, not that of any server.
+func (user *UserAuthQuery) Watchlist(ctx context.Context, start, size int) (*MediaContainer, error) {
+	params := url.Values{}
+	params.Set("includeCollections", "1")
+	setPaging(params, start, size)
+
+	var container MediaContainer
+	uri := metadataProviderUri + "/library/sections/watchlist/all?" + params.Encode()
+	err := user.plexTv(ctx, "GET", uri, nil, &container)
+	if err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
+
+func (user *UserAuthQuery) watchlistAction(ctx context.Context, action, guid string) error {
+	params := url.Values{}
+	params.Set("ratingKey", discoverRatingKey(guid))
+
+	return user.plexTv(ctx, "PUT", metadataProviderUri+"/actions/"+action+"?"+params.Encode(), nil, nil)
+}
+
+// AddToWatchlist adds the movie or show with the given Plex guid (e.g.
+// plex://movie/5d7768...) to the user's Watchlist.
+func (user *UserAuthQuery) AddToWatchlist(ctx context.Context, guid string) error {
+	return user.watchlistAction(ctx, "addToWatchlist", guid)
+}
+
+// RemoveFromWatchlist takes the movie or show with the given Plex guid off
+// the user's Watchlist.
+func (user *UserAuthQuery) RemoveFromWatchlist(ctx context.Context, guid string) error {
+	return user.watchlistAction(ctx, "removeFromWatchlist", guid)
+}
+
+// IsWatchlisted tells whether the movie or show with the given Plex guid
+// is on the user's Watchlist.
+func (user *UserAuthQuery) IsWatchlisted(ctx context.Context, guid string) (bool, error) {
+	var q struct {
+		UserState struct {
+			WatchlistedAt int64 `xml:"watchlistedAt,attr"`
+		} `xml:"UserState"`
+	}
+	uri := metadataProviderUri + "/library/metadata/" + url.PathEscape(discoverRatingKey(guid)) + "/userState"
+	err := user.plexTv(ctx, "GET", uri, nil, &q)
+	if err != nil {
+		return false, err
+	}
+
+	return q.UserState.WatchlistedAt != 0, nil
+}
@@ -0,0 +1,196 @@
+// Package webhooks receives the events Plex Media Server posts to webhook
+// urls registered on the account (see UserAuthQuery.AddWebhook).
+//
+// Plex sends each event as a multipart form with the event in a JSON
+// "payload" field and, for some events, a JPEG in a "thumb" file field.
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	EventPlay     = "media.play"
+	EventPause    = "media.pause"
+	EventResume   = "media.resume"
+	EventStop     = "media.stop"
+	EventScrobble = "media.scrobble"
+	EventRate     = "media.rate"
+
+	EventLibraryOnDeck = "library.on.deck"
+	EventLibraryNew    = "library.new"
+
+	EventDatabaseBackup    = "admin.database.backup"
+	EventDatabaseCorrupted = "admin.database.corrupted"
+	EventDeviceNew         = "device.new"
+	EventPlaybackStarted   = "playback.started"
+)
+
+type Event struct {
+	Type    string  `json:"event"`
+	IsUser  bool    `json:"user"`
+	IsOwner bool    `json:"owner"`
+	Rating  float64 `json:"rating"`
+
+	Account  *Account  `json:"Account"`
+	Server   *Server   `json:"Server"`
+	Player   *Player   `json:"Player"`
+	Metadata *Metadata `json:"Metadata"`
+
+	// Thumbnail is the JPEG poster Plex attached to the event, if any.
+	Thumbnail []byte `json:"-"`
+}
+
+type Account struct {
+	Id    int    `json:"id"`
+	Title string `json:"title"`
+	Thumb string `json:"thumb"`
+}
+
+type Server struct {
+	Title string `json:"title"`
+	Uuid  string `json:"uuid"`
+}
+
+type Player struct {
+	Title         string `json:"title"`
+	Uuid          string `json:"uuid"`
+	PublicAddress string `json:"publicAddress"`
+	IsLocal       bool   `json:"local"`
+}
+
+type Metadata struct {
+	LibrarySectionType  string `json:"librarySectionType"`
+	LibrarySectionTitle string `json:"librarySectionTitle"`
+	LibrarySectionID    int    `json:"librarySectionID"`
+	RatingKey           string `json:"ratingKey"`
+	Key                 string `json:"key"`
+	Guid                string `json:"guid"`
+	Type                string `json:"type"`
+	Title               string `json:"title"`
+	ParentTitle         string `json:"parentTitle"`
+	GrandparentTitle    string `json:"grandparentTitle"`
+	Index               int    `json:"index"`
+	ParentIndex         int    `json:"parentIndex"`
+	Summary             string `json:"summary"`
+	Year                int    `json:"year"`
+	Duration            int64  `json:"duration"`
+	ViewOffset          int64  `json:"viewOffset"`
+	Thumb               string `json:"thumb"`
+	Art                 string `json:"art"`
+	AddedAt             int64  `json:"addedAt"`
+	UpdatedAt           int64  `json:"updatedAt"`
+}
+
+// DefaultMaxMemory is how much of a request ParseMultipartForm keeps in
+// memory; thumbnails are small so this is rarely exceeded.
+const DefaultMaxMemory = 1 << 20
+
+// Handler is an http.Handler that decodes webhook events and hands them to
+// the callbacks registered with On and OnAll. Callbacks run synchronously,
+// before Plex gets its response, so slow work should be moved elsewhere.
+type Handler struct {
+	MaxMemory int64
+
+	lock      sync.RWMutex
+	callbacks map[string][]func(*Event)
+	all       []func(*Event)
+}
+
+func NewHandler() *Handler {
+	return &Handler{
+		MaxMemory: DefaultMaxMemory,
+		callbacks: make(map[string][]func(*Event)),
+	}
+}
+
+// On calls callback for every event of the given type, e.g. EventScrobble.
+func (handler *Handler) On(eventType string, callback func(*Event)) {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+
+	if handler.callbacks == nil {
+		handler.callbacks = make(map[string][]func(*Event))
+	}
+	handler.callbacks[eventType] = append(handler.callbacks[eventType], callback)
+}
+
+// OnAll calls callback for every event.
+func (handler *Handler) OnAll(callback func(*Event)) {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+
+	handler.all = append(handler.all, callback)
+}
+
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	event, err := handler.Parse(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	handler.dispatch(event)
+	w.WriteHeader(http.StatusOK)
+}
+
+// Parse decodes the event from a webhook request without dispatching it.
+func (handler *Handler) Parse(r *http.Request) (*Event, error) {
+	maxMemory := handler.MaxMemory
+	if maxMemory <= 0 {
+		maxMemory = DefaultMaxMemory
+	}
+
+	err := r.ParseMultipartForm(maxMemory)
+	if err != nil {
+		return nil, err
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	payload := r.MultipartForm.Value["payload"]
+	if len(payload) == 0 {
+		return nil, errors.New("webhooks: request has no payload")
+	}
+
+	var event Event
+	err = json.Unmarshal([]byte(payload[0]), &event)
+	if err != nil {
+		return nil, err
+	}
+
+	if thumbs := r.MultipartForm.File["thumb"]; len(thumbs) > 0 {
+		file, err := thumbs[0].Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		event.Thumbnail, err = io.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &event, nil
+}
+
+func (handler *Handler) dispatch(event *Event) {
+	handler.lock.RLock()
+	callbacks := append(handler.callbacks[event.Type][:0:0], handler.callbacks[event.Type]...)
+	callbacks = append(callbacks, handler.all...)
+	handler.lock.RUnlock()
+
+	for _, callback := range callbacks {
+		callback(event)
+	}
+}
@@ -0,0 +1,207 @@
+package goplex
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// A minimal RFC 6455 client, just enough for the media server's
+// notification socket. The handshake goes through net/http so the
+// Client's transport, proxy and TLS settings apply.
+
+const websocketGuid = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xa
+)
+
+// messages bigger than this are refused rather than buffered
+const wsMaxMessageSize = 16 << 20
+
+var errWebsocketClosed = errors.New("websocket closed by server")
+
+type wsConn struct {
+	conn   io.ReadWriteCloser
+	reader *bufio.Reader
+
+	writeLock sync.Mutex
+}
+
+func dialWebsocket(httpClient *http.Client, request *http.Request) (*wsConn, error) {
+	nonce := make([]byte, 16)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	request.Header.Set("Sec-WebSocket-Key", key)
+
+	// the connection lives far longer than any request timeout
+	client := *httpClient
+	client.Timeout = 0
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		defer response.Body.Close()
+		return nil, newHttpStatusError(response)
+	}
+
+	conn, ok := response.Body.(io.ReadWriteCloser)
+	if !ok {
+		response.Body.Close()
+		return nil, errors.New("websocket: connection can't be upgraded")
+	}
+
+	accept := sha1.Sum([]byte(key + websocketGuid))
+	if response.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(accept[:]) {
+		conn.Close()
+		return nil, errors.New("websocket: bad Sec-WebSocket-Accept from server")
+	}
+
+	return &wsConn{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}, nil
+}
+
+// ReadMessage returns the next text or binary message, answering pings
+// and reassembling fragmented messages along the way.
+func (ws *wsConn) ReadMessage() ([]byte, error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := ws.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case wsOpPing:
+			err = ws.writeFrame(wsOpPong, payload)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			ws.writeFrame(wsOpClose, payload)
+			return nil, errWebsocketClosed
+		}
+
+		message = append(message, payload...)
+		if len(message) > wsMaxMessageSize {
+			return nil, errors.New("websocket: message too large")
+		}
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+func (ws *wsConn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	_, err := io.ReadFull(ws.reader, header[:])
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		var extended [2]byte
+		_, err = io.ReadFull(ws.reader, extended[:])
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		_, err = io.ReadFull(ws.reader, extended[:])
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	if err != nil {
+		return false, 0, nil, err
+	}
+	if length > wsMaxMessageSize {
+		return false, 0, nil, errors.New("websocket: frame too large")
+	}
+
+	var mask [4]byte
+	if masked {
+		_, err = io.ReadFull(ws.reader, mask[:])
+		if err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(ws.reader, payload)
+	if err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// writeFrame sends a single, final frame. Client frames must be masked.
+func (ws *wsConn) writeFrame(opcode byte, payload []byte) error {
+	ws.writeLock.Lock()
+	defer ws.writeLock.Unlock()
+
+	frame := []byte{0x80 | opcode}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, 0x80|byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(payload)))
+	}
+
+	var mask [4]byte
+	_, err := rand.Read(mask[:])
+	if err != nil {
+		return err
+	}
+	frame = append(frame, mask[:]...)
+
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	_, err = ws.conn.Write(frame)
+	return err
+}
+
+func (ws *wsConn) Close() error {
+	ws.writeFrame(wsOpClose, []byte{0x03, 0xe8}) // 1000, normal closure
+	return ws.conn.Close()
+}
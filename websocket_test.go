@@ -0,0 +1,144 @@
+package goplex
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+// fakeSocket feeds the frames in in to a wsConn and records what it
+// writes to out.
+type fakeSocket struct {
+	in  io.Reader
+	out bytes.Buffer
+}
+
+func (socket *fakeSocket) Read(p []byte) (int, error)  { return socket.in.Read(p) }
+func (socket *fakeSocket) Write(p []byte) (int, error) { return socket.out.Write(p) }
+func (socket *fakeSocket) Close() error                { return nil }
+
+func newFakeWsConn(in []byte) (*wsConn, *fakeSocket) {
+	socket := &fakeSocket{in: bytes.NewReader(in)}
+	return &wsConn{conn: socket, reader: bufio.NewReader(socket)}, socket
+}
+
+// serverFrame builds an unmasked frame, as a server sends them.
+func serverFrame(fin bool, opcode byte, payload []byte) []byte {
+	first := opcode
+	if fin {
+		first |= 0x80
+	}
+	frame := []byte{first}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(payload)))
+	}
+	return append(frame, payload...)
+}
+
+func TestWebsocketWriteFrame(t *testing.T) {
+	for _, size := range []int{0, 5, 125, 126, 200, 0xffff, 70000} {
+		payload := bytes.Repeat([]byte{'x'}, size)
+
+		writer, socket := newFakeWsConn(nil)
+		err := writer.writeFrame(wsOpText, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		written := socket.out.Bytes()
+		if written[0] != 0x80|wsOpText {
+			t.Errorf("%d bytes: first byte %#x, want a final text frame", size, written[0])
+		}
+		if written[1]&0x80 == 0 {
+			t.Errorf("%d bytes: client frame isn't masked", size)
+		}
+
+		reader, _ := newFakeWsConn(written)
+		fin, opcode, read, err := reader.readFrame()
+		if err != nil {
+			t.Fatalf("%d bytes: %v", size, err)
+		}
+		if !fin || opcode != wsOpText || !bytes.Equal(read, payload) {
+			t.Errorf("%d bytes: read back fin=%v opcode=%#x and %d bytes", size, fin, opcode, len(read))
+		}
+	}
+}
+
+func TestWebsocketReadMessage(t *testing.T) {
+	var in []byte
+	in = append(in, serverFrame(false, wsOpText, []byte(`{"Notification`))...)
+	in = append(in, serverFrame(true, wsOpPing, []byte("ping"))...)
+	in = append(in, serverFrame(true, wsOpContinuation, []byte(`Container":{}}`))...)
+	in = append(in, serverFrame(true, wsOpText, bytes.Repeat([]byte{'y'}, 300))...)
+
+	ws, socket := newFakeWsConn(in)
+
+	message, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(message) != `{"NotificationContainer":{}}` {
+		t.Errorf("got %q", message)
+	}
+
+	// the ping was answered with a pong carrying the same payload
+	reader, _ := newFakeWsConn(socket.out.Bytes())
+	_, opcode, payload, err := reader.readFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opcode != wsOpPong || string(payload) != "ping" {
+		t.Errorf("answered with opcode %#x and %q", opcode, payload)
+	}
+
+	message, err = ws.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(message) != 300 {
+		t.Errorf("got %d bytes, want 300", len(message))
+	}
+
+	_, err = ws.ReadMessage()
+	if err != io.EOF {
+		t.Errorf("got %v at the end of the stream, want EOF", err)
+	}
+}
+
+func TestWebsocketReadMessageClose(t *testing.T) {
+	ws, socket := newFakeWsConn(serverFrame(true, wsOpClose, []byte{0x03, 0xe8}))
+
+	_, err := ws.ReadMessage()
+	if err != errWebsocketClosed {
+		t.Fatalf("got %v, want errWebsocketClosed", err)
+	}
+
+	reader, _ := newFakeWsConn(socket.out.Bytes())
+	_, opcode, _, err := reader.readFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opcode != wsOpClose {
+		t.Errorf("answered with opcode %#x, want a close", opcode)
+	}
+}
+
+func TestWebsocketFrameTooLarge(t *testing.T) {
+	header := []byte{0x80 | wsOpBinary, 127}
+	header = binary.BigEndian.AppendUint64(header, wsMaxMessageSize+1)
+
+	ws, _ := newFakeWsConn(header)
+	_, _, _, err := ws.readFrame()
+	if err == nil {
+		t.Error("expected an error for an oversized frame")
+	}
+}